@@ -1,14 +1,36 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"math/bits"
+	"math/cmplx"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // Calculator represents a simple calculator for basic arithmetic operations.
 // This serves as a baseline project for bug injection testing.
-type Calculator struct{}
+//
+// A Calculator is safe for concurrent use by multiple goroutines. Its only state
+// is the memory register and the operation history, which are guarded by a
+// mutex; the other methods are pure and take no locks.
+type Calculator struct {
+	// recordHistory enables appending to history; see EnableHistory. It is
+	// atomic so that operations can check it without taking mu.
+	recordHistory atomic.Bool
+
+	mu sync.Mutex
+	// memory is the running memory register used by the Memory methods.
+	memory  float64
+	history []Operation
+}
 
 // NewCalculator creates a new Calculator instance.
 func NewCalculator() *Calculator {
@@ -17,21 +39,34 @@ func NewCalculator() *Calculator {
 
 // Add adds two numbers.
 func (c *Calculator) Add(a, b float64) float64 {
-	return a + b
+	result := a + b
+	c.record("Add", result, nil, a, b)
+	return result
 }
 
 // Subtract subtracts the second number from the first.
 func (c *Calculator) Subtract(a, b float64) float64 {
-	return a - b
+	result := a - b
+	c.record("Subtract", result, nil, a, b)
+	return result
 }
 
 // Multiply multiplies two numbers.
 func (c *Calculator) Multiply(a, b float64) float64 {
-	return a * b
+	result := a * b
+	c.record("Multiply", result, nil, a, b)
+	return result
 }
 
 // Divide divides the first number by the second.
-func (c *Calculator) Divide(a, b float64) (float64, error) {
+func (c *Calculator) Divide(a, b float64) (result float64, err error) {
+	defer func() { c.record("Divide", result, err, a, b) }()
+	return divide(a, b)
+}
+
+// divide divides a by b without recording history, for use by methods that
+// divide as part of a larger calculation.
+func divide(a, b float64) (float64, error) {
 	if b == 0 {
 		return 0, errors.New("division by zero")
 	}
@@ -40,19 +75,99 @@ func (c *Calculator) Divide(a, b float64) (float64, error) {
 
 // Power calculates the power of a number.
 func (c *Calculator) Power(base, exponent float64) float64 {
-	return math.Pow(base, exponent)
+	result := math.Pow(base, exponent)
+	c.record("Power", result, nil, base, exponent)
+	return result
+}
+
+// PowerInt calculates base raised to a non-negative integer exponent exactly,
+// using exponentiation by squaring. It returns an error if the result does not
+// fit in an int.
+func (c *Calculator) PowerInt(base, exponent int) (int, error) {
+	if exponent < 0 {
+		return 0, errors.New("exponent cannot be negative")
+	}
+
+	overflow := errors.New("power result overflows int")
+	b := uint(base)
+	if base < 0 {
+		b = -b
+	}
+	result := uint(1)
+	for e := exponent; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			hi, lo := bits.Mul(result, b)
+			if hi != 0 {
+				return 0, overflow
+			}
+			result = lo
+		}
+		// The square is only needed, and only has to fit, if bits remain.
+		if e > 1 {
+			hi, lo := bits.Mul(b, b)
+			if hi != 0 {
+				return 0, overflow
+			}
+			b = lo
+		}
+	}
+
+	if base < 0 && exponent%2 == 1 {
+		if result > math.MaxInt+1 {
+			return 0, overflow
+		}
+		return int(-result), nil
+	}
+	if result > math.MaxInt {
+		return 0, overflow
+	}
+	return int(result), nil
 }
 
 // Sqrt calculates the square root of a number.
-func (c *Calculator) Sqrt(number float64) (float64, error) {
+func (c *Calculator) Sqrt(number float64) (result float64, err error) {
+	defer func() { c.record("Sqrt", result, err, number) }()
+	return sqrt(number)
+}
+
+// sqrt is Sqrt without recording history.
+func sqrt(number float64) (float64, error) {
 	if number < 0 {
 		return 0, errors.New("cannot calculate square root of negative number")
 	}
 	return math.Sqrt(number), nil
 }
 
+// NthRoot calculates the nth root of a number. Odd integer roots of negative
+// numbers return the real negative root, and n may be fractional or negative.
+func (c *Calculator) NthRoot(number, n float64) (float64, error) {
+	if n == 0 {
+		return 0, errors.New("root degree cannot be zero")
+	}
+	if number == 0 && n < 0 {
+		return 0, errors.New("division by zero")
+	}
+
+	if n == math.Trunc(n) {
+		root, err := realRoot(number, math.Abs(n))
+		if err != nil {
+			return 0, err
+		}
+		if n < 0 {
+			return 1 / root, nil
+		}
+		return root, nil
+	}
+
+	if number < 0 {
+		return 0, errors.New("cannot calculate non-integer root of negative number")
+	}
+	return math.Pow(number, 1/n), nil
+}
+
 // Factorial calculates the factorial of a non-negative integer.
-func (c *Calculator) Factorial(n int) (int, error) {
+func (c *Calculator) Factorial(n int) (result int, err error) {
+	defer func() { c.record("Factorial", float64(result), err, float64(n)) }()
 	if n < 0 {
 		return 0, errors.New("factorial is not defined for negative numbers")
 	}
@@ -60,15 +175,33 @@ func (c *Calculator) Factorial(n int) (int, error) {
 		return 1, nil
 	}
 
-	result := 1
+	result = 1
 	for i := 2; i <= n; i++ {
+		if result > math.MaxInt/i {
+			return 0, errors.New("factorial result overflows int")
+		}
 		result *= i
 	}
 	return result, nil
 }
 
+// FactorialBig calculates the factorial of a non-negative integer exactly,
+// without the size limit of Factorial.
+func (c *Calculator) FactorialBig(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, errors.New("factorial is not defined for negative numbers")
+	}
+	return new(big.Int).MulRange(1, int64(n)), nil
+}
+
 // Modulo calculates the modulo of two numbers.
-func (c *Calculator) Modulo(a, b float64) (float64, error) {
+func (c *Calculator) Modulo(a, b float64) (result float64, err error) {
+	defer func() { c.record("Modulo", result, err, a, b) }()
+	return modulo(a, b)
+}
+
+// modulo is Modulo without recording history.
+func modulo(a, b float64) (float64, error) {
 	if b == 0 {
 		return 0, errors.New("modulo by zero")
 	}
@@ -99,16 +232,81 @@ func (c *Calculator) GCD(a, b int) int {
 	return a
 }
 
-// LCM calculates the least common multiple of two integers.
+// BinaryGCD calculates the greatest common divisor of two integers using Stein's
+// algorithm, which needs only subtraction and bit shifts.
+func (c *Calculator) BinaryGCD(a, b int) int {
+	u, v := uint(a), uint(b)
+	if a < 0 {
+		u = -u
+	}
+	if b < 0 {
+		v = -v
+	}
+	if u == 0 {
+		return int(v)
+	}
+	if v == 0 {
+		return int(u)
+	}
+
+	// Factor out the common powers of two, then keep both values odd.
+	shift := bits.TrailingZeros(u | v)
+	u >>= bits.TrailingZeros(u)
+	for v != 0 {
+		v >>= bits.TrailingZeros(v)
+		if u > v {
+			u, v = v, u
+		}
+		v -= u
+	}
+	return int(u << shift)
+}
+
+// LCM calculates the least common multiple of two integers. Dividing by the GCD
+// before multiplying keeps intermediate values small, but a result too large
+// for int still wraps around; use LCMChecked to detect that.
 func (c *Calculator) LCM(a, b int) int {
-	return int(math.Abs(float64(a*b)) / float64(c.GCD(a, b)))
+	if a == 0 || b == 0 {
+		return 0
+	}
+	result := a / c.BinaryGCD(a, b) * b
+	if result < 0 {
+		result = -result
+	}
+	return result
+}
+
+// LCMChecked calculates the least common multiple of two integers, returning an
+// error if it does not fit in an int.
+func (c *Calculator) LCMChecked(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	u, v := uint(a), uint(b)
+	if a < 0 {
+		u = -u
+	}
+	if b < 0 {
+		v = -v
+	}
+	g := uint(c.BinaryGCD(a, b))
+	hi, lo := bits.Mul(u/g, v)
+	if hi != 0 || lo > math.MaxInt {
+		return 0, errors.New("lcm result overflows int")
+	}
+	return int(lo), nil
 }
 
-// IsPrime checks if a number is prime.
+// IsPrime checks if a number is prime. Numbers above trialDivisionLimit are
+// tested with IsPrimeFast instead of trial division.
 func (c *Calculator) IsPrime(n int) bool {
 	if n < 2 {
 		return false
 	}
+	if n > trialDivisionLimit {
+		return c.IsPrimeFast(n)
+	}
 	if n == 2 {
 		return true
 	}
@@ -134,6 +332,29 @@ func (c *Calculator) Max(a, b float64) float64 {
 	return math.Max(a, b)
 }
 
+// Clamp constrains value to the range [min, max].
+func (c *Calculator) Clamp(value, min, max float64) (float64, error) {
+	if min > max {
+		return 0, errors.New("lower bound must not exceed upper bound")
+	}
+	return math.Max(min, math.Min(value, max)), nil
+}
+
+// Lerp linearly interpolates from a to b, returning a at t = 0 and b at t = 1.
+// Values of t outside [0, 1] extrapolate.
+func (c *Calculator) Lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// MapRange linearly maps value from the range [inMin, inMax] onto
+// [outMin, outMax]. Values outside the input range are extrapolated.
+func (c *Calculator) MapRange(value, inMin, inMax, outMin, outMax float64) (float64, error) {
+	if inMin == inMax {
+		return 0, errors.New("input range must have non-zero width")
+	}
+	return c.Lerp(outMin, outMax, (value-inMin)/(inMax-inMin)), nil
+}
+
 // Ceil returns the ceiling of a number.
 func (c *Calculator) Ceil(number float64) float64 {
 	return math.Ceil(number)
@@ -160,6 +381,42 @@ func (c *Calculator) Log10(number float64) (float64, error) {
 	return math.Log10(number), nil
 }
 
+// Log2 calculates the base-2 logarithm of a number.
+func (c *Calculator) Log2(number float64) (float64, error) {
+	if number <= 0 {
+		return 0, errors.New("logarithm is not defined for non-positive numbers")
+	}
+	return math.Log2(number), nil
+}
+
+// LogBase calculates the logarithm of a number in the given base.
+func (c *Calculator) LogBase(number, base float64) (float64, error) {
+	if number <= 0 {
+		return 0, errors.New("logarithm is not defined for non-positive numbers")
+	}
+	if base <= 0 || base == 1 {
+		return 0, errors.New("logarithm base must be positive and not equal to 1")
+	}
+	return math.Log(number) / math.Log(base), nil
+}
+
+// ILogBase returns floor(log_base(n)) computed exactly with integer division.
+func (c *Calculator) ILogBase(n, base int) (int, error) {
+	if n <= 0 {
+		return 0, errors.New("logarithm is not defined for non-positive numbers")
+	}
+	if base < 2 {
+		return 0, errors.New("logarithm base must be greater than 1")
+	}
+
+	result := 0
+	for n >= base {
+		n /= base
+		result++
+	}
+	return result, nil
+}
+
 // Sin calculates the sine of an angle in radians.
 func (c *Calculator) Sin(angle float64) float64 {
 	return math.Sin(angle)
@@ -175,6 +432,33 @@ func (c *Calculator) Tan(angle float64) float64 {
 	return math.Tan(angle)
 }
 
+// Asin calculates the arcsine of x in radians, in the range [-π/2, π/2].
+func (c *Calculator) Asin(x float64) (float64, error) {
+	if x < -1 || x > 1 {
+		return 0, errors.New("arcsine is only defined on [-1, 1]")
+	}
+	return math.Asin(x), nil
+}
+
+// Acos calculates the arccosine of x in radians, in the range [0, π].
+func (c *Calculator) Acos(x float64) (float64, error) {
+	if x < -1 || x > 1 {
+		return 0, errors.New("arccosine is only defined on [-1, 1]")
+	}
+	return math.Acos(x), nil
+}
+
+// Atan calculates the arctangent of x in radians, in the range (-π/2, π/2).
+func (c *Calculator) Atan(x float64) float64 {
+	return math.Atan(x)
+}
+
+// Atan2 calculates the angle in radians of the point (x, y) from the positive
+// x-axis, using the signs of both arguments to pick the quadrant.
+func (c *Calculator) Atan2(y, x float64) float64 {
+	return math.Atan2(y, x)
+}
+
 // DegreesToRadians converts degrees to radians.
 func (c *Calculator) DegreesToRadians(degrees float64) float64 {
 	return degrees * math.Pi / 180
@@ -185,6 +469,2704 @@ func (c *Calculator) RadiansToDegrees(radians float64) float64 {
 	return radians * 180 / math.Pi
 }
 
+// SinDeg calculates the sine of an angle in degrees.
+func (c *Calculator) SinDeg(degrees float64) float64 {
+	return c.Sin(c.DegreesToRadians(degrees))
+}
+
+// CosDeg calculates the cosine of an angle in degrees.
+func (c *Calculator) CosDeg(degrees float64) float64 {
+	return c.Cos(c.DegreesToRadians(degrees))
+}
+
+// TanDeg calculates the tangent of an angle in degrees. Near odd multiples of
+// 90 degrees the result is very large rather than infinite.
+func (c *Calculator) TanDeg(degrees float64) float64 {
+	return c.Tan(c.DegreesToRadians(degrees))
+}
+
+// FiniteProduct calculates the product of f(k) for k from `from` to `to` inclusive.
+// The range always has at least one term: an empty range (from > to) returns an
+// error rather than the empty product 1.
+func (c *Calculator) FiniteProduct(f func(int) float64, from, to int) (float64, error) {
+	if from > to {
+		return 0, errors.New("range start must not exceed range end")
+	}
+
+	result := 1.0
+	// Stop after k == to, since k++ would wrap around when to == math.MaxInt.
+	for k := from; ; k++ {
+		result *= f(k)
+		if k == to {
+			break
+		}
+	}
+	return result, nil
+}
+
+// FiniteSum calculates the sum of f(k) for k from `from` to `to` inclusive.
+func (c *Calculator) FiniteSum(f func(int) float64, from, to int) (float64, error) {
+	if from > to {
+		return 0, errors.New("range start must not exceed range end")
+	}
+
+	result := 0.0
+	// Stop after k == to, since k++ would wrap around when to == math.MaxInt.
+	for k := from; ; k++ {
+		result += f(k)
+		if k == to {
+			break
+		}
+	}
+	return result, nil
+}
+
+// Bernoulli calculates the nth Bernoulli number as an exact rational using the
+// Akiyama-Tanigawa algorithm. This algorithm yields the convention B1 = +1/2.
+func (c *Calculator) Bernoulli(n int) (*big.Rat, error) {
+	if n < 0 {
+		return nil, errors.New("bernoulli number is not defined for negative indices")
+	}
+
+	a := make([]*big.Rat, n+1)
+	for m := 0; m <= n; m++ {
+		a[m] = big.NewRat(1, int64(m+1))
+		for j := m; j >= 1; j-- {
+			a[j-1].Sub(a[j-1], a[j])
+			a[j-1].Mul(a[j-1], big.NewRat(int64(j), 1))
+		}
+	}
+	return a[0], nil
+}
+
+// ZetaEven calculates the rational coefficient r such that ζ(n) = r·π^n for a
+// positive even integer n, using ζ(2k) = (-1)^(k+1)·B(2k)·(2π)^(2k) / (2·(2k)!).
+func (c *Calculator) ZetaEven(n int) (*big.Rat, error) {
+	if n <= 0 || n%2 != 0 {
+		return nil, errors.New("exact zeta value requires a positive even integer")
+	}
+
+	bernoulli, err := c.Bernoulli(n)
+	if err != nil {
+		return nil, err
+	}
+
+	numerator := new(big.Int).Lsh(big.NewInt(1), uint(n-1))
+	denominator := new(big.Int).MulRange(1, int64(n))
+	result := new(big.Rat).SetFrac(numerator, denominator)
+	result.Mul(result, new(big.Rat).Abs(bernoulli))
+	return result, nil
+}
+
+// Zeta approximates the Riemann zeta function by summing 1/k^s for the given
+// number of terms. Terms are added smallest first to reduce rounding error.
+func (c *Calculator) Zeta(s float64, terms int) (float64, error) {
+	if s <= 1 {
+		return 0, errors.New("zeta series diverges for s <= 1")
+	}
+	if terms < 1 {
+		return 0, errors.New("number of terms must be at least 1")
+	}
+
+	result := 0.0
+	for k := terms; k >= 1; k-- {
+		result += 1 / math.Pow(float64(k), s)
+	}
+	return result, nil
+}
+
+// DivisorPairs returns each pair (d, n/d) of divisors of n with d <= n/d,
+// ordered by increasing d.
+func (c *Calculator) DivisorPairs(n int) ([][2]int, error) {
+	if n < 1 {
+		return nil, errors.New("divisors are only defined for positive integers")
+	}
+
+	var pairs [][2]int
+	for d := 1; d <= n/d; d++ {
+		if n%d == 0 {
+			pairs = append(pairs, [2]int{d, n / d})
+		}
+	}
+	return pairs, nil
+}
+
+// SigmaDivisors calculates σ_k(n), the sum of the k-th powers of the divisors of n.
+// σ_0 is the number of divisors and σ_1 is the sum of divisors.
+func (c *Calculator) SigmaDivisors(n, k int) (int, error) {
+	if k < 0 {
+		return 0, errors.New("divisor power must be non-negative")
+	}
+	pairs, err := c.DivisorPairs(n)
+	if err != nil {
+		return 0, err
+	}
+
+	overflow := errors.New("divisor sum overflows int")
+	sum := 0
+	for _, pair := range pairs {
+		divisors := pair[:]
+		if pair[0] == pair[1] {
+			divisors = pair[:1]
+		}
+		for _, d := range divisors {
+			term, err := c.PowerInt(d, k)
+			if err != nil {
+				return 0, overflow
+			}
+			if sum > math.MaxInt-term {
+				return 0, overflow
+			}
+			sum += term
+		}
+	}
+	return sum, nil
+}
+
+// Mobius calculates the Möbius function of n: 0 if n has a squared prime factor,
+// otherwise (-1) raised to the number of distinct prime factors.
+func (c *Calculator) Mobius(n int) (int, error) {
+	if n < 1 {
+		return 0, errors.New("mobius function is only defined for positive integers")
+	}
+
+	result := 1
+	factors := primeFactors(n)
+	for i, p := range factors {
+		if i > 0 && factors[i-1] == p {
+			return 0, nil
+		}
+		result = -result
+	}
+	return result, nil
+}
+
+// primeFactors returns the prime factors of n in ascending order with
+// multiplicity using trial division. It returns nil for n < 2.
+func primeFactors(n int) []int {
+	var factors []int
+	for n%2 == 0 && n > 1 {
+		factors = append(factors, 2)
+		n /= 2
+	}
+	for p := 3; p <= n/p; p += 2 {
+		for n%p == 0 {
+			factors = append(factors, p)
+			n /= p
+		}
+	}
+	if n > 1 {
+		factors = append(factors, n)
+	}
+	return factors
+}
+
+// maxPascalRow is the last row of Pascal's triangle whose entries all fit in a
+// 64-bit int; C(67, 33) does not.
+const maxPascalRow = 66
+
+// PascalRow returns the nth row of Pascal's triangle, C(n,0) through C(n,n).
+// Rows are built by summing adjacent entries, so no factorials are computed.
+func (c *Calculator) PascalRow(n int) ([]int, error) {
+	if n < 0 {
+		return nil, errors.New("pascal row index must be non-negative")
+	}
+	// Later rows overflow even a 64-bit int, so reject them before allocating.
+	// Narrower ints are caught by the check in the loop.
+	if n > maxPascalRow {
+		return nil, errors.New("pascal row overflows int")
+	}
+
+	row := make([]int, 1, n+1)
+	row[0] = 1
+	for i := 1; i <= n; i++ {
+		row = append(row, 1)
+		for j := i - 1; j > 0; j-- {
+			if row[j] > math.MaxInt-row[j-1] {
+				return nil, errors.New("pascal row overflows int")
+			}
+			row[j] += row[j-1]
+		}
+	}
+	return row, nil
+}
+
+// PSquareEstimator approximates a percentile of a data stream using the P² algorithm
+// of Jain and Chlamtac, keeping only five markers instead of every observation.
+type PSquareEstimator struct {
+	p         float64
+	count     int
+	heights   [5]float64
+	positions [5]float64
+	desired   [5]float64
+	increment [5]float64
+}
+
+// NewPSquare creates a PSquareEstimator for the percentile p, given as a fraction in (0, 1).
+func NewPSquare(p float64) (*PSquareEstimator, error) {
+	if p <= 0 || p >= 1 {
+		return nil, errors.New("percentile must be between 0 and 1 exclusive")
+	}
+	return &PSquareEstimator{
+		p:         p,
+		desired:   [5]float64{1, 1 + 2*p, 1 + 4*p, 3 + 2*p, 5},
+		increment: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}, nil
+}
+
+// Push adds an observation to the estimator.
+func (e *PSquareEstimator) Push(x float64) {
+	if e.count < 5 {
+		e.heights[e.count] = x
+		e.count++
+		if e.count == 5 {
+			sort.Float64s(e.heights[:])
+			e.positions = [5]float64{1, 2, 3, 4, 5}
+		}
+		return
+	}
+	e.count++
+
+	// Locate the cell containing x, extending the extreme markers if needed.
+	var k int
+	switch {
+	case x < e.heights[0]:
+		e.heights[0] = x
+		k = 0
+	case x >= e.heights[4]:
+		e.heights[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3; k++ {
+			if x < e.heights[k+1] {
+				break
+			}
+		}
+	}
+
+	for i := k + 1; i < 5; i++ {
+		e.positions[i]++
+	}
+	for i := range e.desired {
+		e.desired[i] += e.increment[i]
+	}
+
+	// Adjust the middle markers toward their desired positions.
+	for i := 1; i <= 3; i++ {
+		d := e.desired[i] - e.positions[i]
+		if (d >= 1 && e.positions[i+1]-e.positions[i] > 1) || (d <= -1 && e.positions[i-1]-e.positions[i] < -1) {
+			step := math.Copysign(1, d)
+			height := e.parabolic(i, step)
+			if height <= e.heights[i-1] || height >= e.heights[i+1] {
+				height = e.linear(i, step)
+			}
+			e.heights[i] = height
+			e.positions[i] += step
+		}
+	}
+}
+
+// parabolic predicts a marker height using the piecewise-parabolic formula.
+func (e *PSquareEstimator) parabolic(i int, d float64) float64 {
+	q, n := e.heights, e.positions
+	return q[i] + d/(n[i+1]-n[i-1])*((n[i]-n[i-1]+d)*(q[i+1]-q[i])/(n[i+1]-n[i])+
+		(n[i+1]-n[i]-d)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+}
+
+// linear predicts a marker height by linear interpolation toward a neighbour.
+func (e *PSquareEstimator) linear(i int, d float64) float64 {
+	j := i + int(d)
+	return e.heights[i] + d*(e.heights[j]-e.heights[i])/(e.positions[j]-e.positions[i])
+}
+
+// Estimate returns the current percentile estimate. Until five observations have
+// been pushed the exact percentile of the stored observations is returned, and
+// NaN is returned when no observations have been pushed.
+func (e *PSquareEstimator) Estimate() float64 {
+	if e.count == 0 {
+		return math.NaN()
+	}
+	if e.count >= 5 {
+		return e.heights[2]
+	}
+
+	samples := make([]float64, e.count)
+	copy(samples, e.heights[:e.count])
+	sort.Float64s(samples)
+	rank := e.p * float64(e.count-1)
+	lower := int(rank)
+	if lower+1 >= len(samples) {
+		return samples[lower]
+	}
+	return samples[lower] + (rank-float64(lower))*(samples[lower+1]-samples[lower])
+}
+
+// WrapRange wraps a value into the half-open interval [lo, hi), as used for
+// periodic quantities such as angles.
+func (c *Calculator) WrapRange(value, lo, hi float64) (float64, error) {
+	if lo >= hi {
+		return 0, errors.New("lower bound must be less than upper bound")
+	}
+
+	span := hi - lo
+	result := value - span*math.Floor((value-lo)/span)
+	if result >= hi {
+		result = lo
+	}
+	return result, nil
+}
+
+// RationalPower calculates base^(num/den), defined as the real den-th root of
+// base^num. Negative bases are allowed unless num is odd and den is even; the
+// result is negative when num and den are both odd.
+func (c *Calculator) RationalPower(base float64, num, den int) (float64, error) {
+	if den == 0 {
+		return 0, errors.New("exponent denominator cannot be zero")
+	}
+	if den < 0 {
+		num, den = -num, -den
+	}
+	if base == 0 && num < 0 {
+		return 0, errors.New("division by zero")
+	}
+	negative := base < 0 && num%2 != 0
+	if negative && den%2 == 0 {
+		return 0, errors.New("cannot calculate even root of negative number")
+	}
+
+	// The sign is settled, so the exponent can be reduced to lowest terms to
+	// work with the smallest root and power of |base|.
+	if g := c.GCD(num, den); g > 1 {
+		num, den = num/g, den/g
+	}
+	root, err := realRoot(math.Abs(base), float64(den))
+	if err != nil {
+		return 0, err
+	}
+	result := math.Pow(root, float64(num))
+	if negative {
+		result = -result
+	}
+	return result, nil
+}
+
+// realRoot calculates the real nth root of x for a positive integer n, returning
+// an exact integer result when x is a perfect nth power.
+func realRoot(x, n float64) (float64, error) {
+	if x < 0 && math.Mod(n, 2) == 0 {
+		return 0, errors.New("cannot calculate even root of negative number")
+	}
+
+	var root float64
+	switch n {
+	case 1:
+		return x, nil
+	case 2:
+		root = math.Sqrt(math.Abs(x))
+	case 3:
+		root = math.Cbrt(math.Abs(x))
+	default:
+		root = math.Pow(math.Abs(x), 1/n)
+		if rounded := math.Round(root); math.Pow(rounded, n) == math.Abs(x) {
+			root = rounded
+		}
+	}
+	return math.Copysign(root, x), nil
+}
+
+// CumulativeMax returns a slice where each element is the maximum of the values
+// up to and including that index.
+func (c *Calculator) CumulativeMax(values []float64) []float64 {
+	result := make([]float64, len(values))
+	for i, v := range values {
+		if i == 0 || v > result[i-1] {
+			result[i] = v
+		} else {
+			result[i] = result[i-1]
+		}
+	}
+	return result
+}
+
+// CumulativeMin returns a slice where each element is the minimum of the values
+// up to and including that index.
+func (c *Calculator) CumulativeMin(values []float64) []float64 {
+	result := make([]float64, len(values))
+	for i, v := range values {
+		if i == 0 || v < result[i-1] {
+			result[i] = v
+		} else {
+			result[i] = result[i-1]
+		}
+	}
+	return result
+}
+
+// CoinChange calculates the number of distinct ways to make amount from the given
+// denominations, where the order of coins does not matter.
+func (c *Calculator) CoinChange(amount int, denominations []int) (int, error) {
+	if err := validateChange(amount, denominations); err != nil {
+		return 0, err
+	}
+
+	ways := make([]int, amount+1)
+	ways[0] = 1
+	for _, coin := range denominations {
+		for value := coin; value <= amount; value++ {
+			if ways[value] > math.MaxInt-ways[value-coin] {
+				return 0, errors.New("number of ways overflows int")
+			}
+			ways[value] += ways[value-coin]
+		}
+	}
+	return ways[amount], nil
+}
+
+// MinCoins calculates the fewest coins from the given denominations that make amount.
+func (c *Calculator) MinCoins(amount int, denominations []int) (int, error) {
+	if err := validateChange(amount, denominations); err != nil {
+		return 0, err
+	}
+
+	// fewest[v] holds the minimum coin count for value v, or -1 if unreachable.
+	fewest := make([]int, amount+1)
+	for value := 1; value <= amount; value++ {
+		fewest[value] = -1
+		for _, coin := range denominations {
+			if coin > value || fewest[value-coin] < 0 {
+				continue
+			}
+			if count := fewest[value-coin] + 1; fewest[value] < 0 || count < fewest[value] {
+				fewest[value] = count
+			}
+		}
+	}
+
+	if fewest[amount] < 0 {
+		return 0, errors.New("cannot make amount with given denominations")
+	}
+	return fewest[amount], nil
+}
+
+// validateChange checks the inputs shared by the coin change methods.
+func validateChange(amount int, denominations []int) error {
+	if amount < 0 {
+		return errors.New("amount cannot be negative")
+	}
+	if len(denominations) == 0 {
+		return errors.New("denominations cannot be empty")
+	}
+	for _, coin := range denominations {
+		if coin <= 0 {
+			return errors.New("denominations must be positive")
+		}
+	}
+	return nil
+}
+
+// IsLeapYear checks if a year is a leap year under the proleptic Gregorian calendar.
+// Years before 1 use astronomical numbering, so year 0 is 1 BCE and is a leap year.
+func (c *Calculator) IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// DayOfWeek calculates the day of the week for a Gregorian date using Zeller's
+// congruence, returning 0 for Sunday through 6 for Saturday.
+func (c *Calculator) DayOfWeek(year, month, day int) (int, error) {
+	if err := c.validateDate(year, month, day); err != nil {
+		return 0, err
+	}
+
+	// Zeller's congruence treats January and February as months 13 and 14
+	// of the previous year.
+	if month < 3 {
+		month += 12
+		year--
+	}
+	h := day + 13*(month+1)/5 + year + floorDiv(year, 4) - floorDiv(year, 100) + floorDiv(year, 400)
+	h = ((h % 7) + 7) % 7
+
+	// Zeller's h counts from Saturday; shift so that Sunday is 0.
+	return (h + 6) % 7, nil
+}
+
+// DaysBetween calculates the number of days from the first date to the second by
+// converting each to a Julian Day Number. The result is negative when the second
+// date is earlier than the first.
+func (c *Calculator) DaysBetween(y1, m1, d1, y2, m2, d2 int) (int, error) {
+	if err := c.validateDate(y1, m1, d1); err != nil {
+		return 0, err
+	}
+	if err := c.validateDate(y2, m2, d2); err != nil {
+		return 0, err
+	}
+	return julianDayNumber(y2, m2, d2) - julianDayNumber(y1, m1, d1), nil
+}
+
+// julianDayNumber converts a Gregorian date to its Julian Day Number. Floor
+// division keeps the leap-year counts correct for years before -4800, where y is
+// negative.
+func julianDayNumber(year, month, day int) int {
+	a := (14 - month) / 12
+	y := year + 4800 - a
+	m := month + 12*a - 3
+	return day + (153*m+2)/5 + 365*y + floorDiv(y, 4) - floorDiv(y, 100) + floorDiv(y, 400) - 32045
+}
+
+// floorDiv divides a by a positive b, rounding toward negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+// validateDate checks that month and day form a valid Gregorian date in year.
+func (c *Calculator) validateDate(year, month, day int) error {
+	if month < 1 || month > 12 {
+		return errors.New("invalid month")
+	}
+
+	daysInMonth := [12]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+	limit := daysInMonth[month-1]
+	if month == 2 && c.IsLeapYear(year) {
+		limit = 29
+	}
+	if day < 1 || day > limit {
+		return errors.New("invalid day")
+	}
+	return nil
+}
+
+// WeightedGeometricMean calculates exp(Σ w·ln(v) / Σ w) for positive values and
+// non-negative weights.
+func (c *Calculator) WeightedGeometricMean(values, weights []float64) (float64, error) {
+	if len(values) != len(weights) {
+		return 0, errors.New("values and weights must have the same length")
+	}
+
+	var logSum, totalWeight float64
+	for i, v := range values {
+		if v <= 0 {
+			return 0, errors.New("geometric mean requires positive values")
+		}
+		if weights[i] < 0 {
+			return 0, errors.New("weights cannot be negative")
+		}
+		logSum += weights[i] * math.Log(v)
+		totalWeight += weights[i]
+	}
+
+	if totalWeight == 0 {
+		return 0, errors.New("total weight must be positive")
+	}
+	return math.Exp(logSum / totalWeight), nil
+}
+
+// Entropy calculates the Shannon entropy -Σ p·log_base(p) of a probability
+// distribution. Zero probabilities contribute nothing to the sum.
+func (c *Calculator) Entropy(probabilities []float64, base float64) (float64, error) {
+	if base <= 1 {
+		return 0, errors.New("logarithm base must be greater than 1")
+	}
+	if err := validateDistribution(probabilities); err != nil {
+		return 0, err
+	}
+
+	result := 0.0
+	for _, p := range probabilities {
+		if p > 0 {
+			result -= p * math.Log(p)
+		}
+	}
+	return result / math.Log(base), nil
+}
+
+// KLDivergence calculates the Kullback-Leibler divergence Σ p·log_base(p/q) of
+// distribution q from distribution p.
+func (c *Calculator) KLDivergence(p, q []float64, base float64) (float64, error) {
+	if err := validateDistributionPair(p, q, base); err != nil {
+		return 0, err
+	}
+
+	result := 0.0
+	for i := range p {
+		if p[i] > 0 {
+			result += p[i] * math.Log(p[i]/q[i])
+		}
+	}
+	return result / math.Log(base), nil
+}
+
+// CrossEntropy calculates the cross-entropy -Σ p·log_base(q) of distribution q
+// relative to distribution p.
+func (c *Calculator) CrossEntropy(p, q []float64, base float64) (float64, error) {
+	if err := validateDistributionPair(p, q, base); err != nil {
+		return 0, err
+	}
+
+	result := 0.0
+	for i := range p {
+		if p[i] > 0 {
+			result -= p[i] * math.Log(q[i])
+		}
+	}
+	return result / math.Log(base), nil
+}
+
+// validateDistributionPair checks the inputs shared by methods comparing two
+// distributions. Every outcome possible under p must also be possible under q.
+func validateDistributionPair(p, q []float64, base float64) error {
+	if len(p) != len(q) {
+		return errors.New("distributions must have the same length")
+	}
+	if base <= 1 {
+		return errors.New("logarithm base must be greater than 1")
+	}
+	if err := validateDistribution(p); err != nil {
+		return err
+	}
+	if err := validateDistribution(q); err != nil {
+		return err
+	}
+	for i := range p {
+		if p[i] > 0 && q[i] == 0 {
+			return errors.New("q must be non-zero wherever p is non-zero")
+		}
+	}
+	return nil
+}
+
+// validateDistribution checks that probabilities are non-negative and sum to 1
+// within a small tolerance.
+func validateDistribution(probabilities []float64) error {
+	sum := 0.0
+	for _, p := range probabilities {
+		if p < 0 {
+			return errors.New("probabilities cannot be negative")
+		}
+		sum += p
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		return errors.New("probabilities must sum to 1")
+	}
+	return nil
+}
+
+// MeanAbsoluteDeviation calculates the average absolute distance of the values
+// from their mean.
+func (c *Calculator) MeanAbsoluteDeviation(values []float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, errors.New("values cannot be empty")
+	}
+
+	center := mean(values)
+	total := 0.0
+	for _, v := range values {
+		total += math.Abs(v - center)
+	}
+	return total / float64(len(values)), nil
+}
+
+// MedianAbsoluteDeviation calculates the median absolute distance of the values
+// from their median. The input slice is not modified.
+func (c *Calculator) MedianAbsoluteDeviation(values []float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, errors.New("values cannot be empty")
+	}
+
+	center := median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - center)
+	}
+	return median(deviations), nil
+}
+
+// median returns the median of a non-empty slice without modifying it.
+func median(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// SlidingVariance calculates the variance of each window of consecutive values,
+// using the sample (N-1) or population (N) denominator. Each window is updated
+// incrementally from the previous one rather than recomputed.
+func (c *Calculator) SlidingVariance(values []float64, window int, sample bool) ([]float64, error) {
+	if window <= 1 {
+		return nil, errors.New("window must be greater than 1")
+	}
+	if window > len(values) {
+		return nil, errors.New("window cannot exceed the number of values")
+	}
+
+	denominator := float64(window)
+	if sample {
+		denominator--
+	}
+
+	// seed computes the mean and sum of squared deviations of the window
+	// ending at index end using Welford's algorithm.
+	seed := func(end int) (float64, float64) {
+		var windowMean, m2 float64
+		for i, v := range values[end-window+1 : end+1] {
+			delta := v - windowMean
+			windowMean += delta / float64(i+1)
+			m2 += delta * (v - windowMean)
+		}
+		return windowMean, m2
+	}
+
+	windowMean, m2 := seed(window - 1)
+	result := make([]float64, 0, len(values)-window+1)
+	result = append(result, m2/denominator)
+	for i := window; i < len(values); i++ {
+		removed, added := values[i-window], values[i]
+		previousMean, previousM2 := windowMean, m2
+		windowMean += (added - removed) / float64(window)
+		m2 += (added - removed) * (added - windowMean + removed - previousMean)
+
+		// When a large value leaves the window the update cancels badly, so
+		// recompute that window exactly.
+		if m2 < previousM2*1e-3 {
+			windowMean, m2 = seed(i)
+		}
+		result = append(result, m2/denominator)
+	}
+	return result, nil
+}
+
+// variance returns the sample or population variance of a slice with enough
+// values for the chosen denominator.
+func variance(values []float64, sample bool) float64 {
+	center := mean(values)
+	total := 0.0
+	for _, v := range values {
+		total += (v - center) * (v - center)
+	}
+	if sample {
+		return total / float64(len(values)-1)
+	}
+	return total / float64(len(values))
+}
+
+// mean returns the arithmetic mean of a non-empty slice.
+func mean(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
+
+// BinarySearchFloat finds, within tol, the greatest x in [lo, hi] satisfying a
+// monotone predicate that holds up to some boundary and fails beyond it.
+func (c *Calculator) BinarySearchFloat(lo, hi float64, pred func(float64) bool, tol float64) (float64, error) {
+	if lo > hi {
+		return 0, errors.New("lower bound must not exceed upper bound")
+	}
+	if tol <= 0 {
+		return 0, errors.New("tolerance must be positive")
+	}
+	if !pred(lo) {
+		return 0, errors.New("predicate is not satisfied at the lower bound")
+	}
+	if pred(hi) {
+		return hi, nil
+	}
+
+	// Invariant: pred(lo) holds and pred(hi) does not.
+	for hi-lo > tol {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if pred(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// ArithmeticGeometricMean calculates the arithmetic-geometric mean of a and b,
+// iterating until the two means agree within tol.
+func (c *Calculator) ArithmeticGeometricMean(a, b float64, tol float64) (float64, error) {
+	if a < 0 || b < 0 {
+		return 0, errors.New("arithmetic-geometric mean requires non-negative inputs")
+	}
+	if tol <= 0 {
+		return 0, errors.New("tolerance must be positive")
+	}
+
+	for math.Abs(a-b) > tol {
+		nextA, nextB := (a+b)/2, math.Sqrt(a*b)
+		if nextA == a && nextB == b {
+			break
+		}
+		a, b = nextA, nextB
+	}
+	return (a + b) / 2, nil
+}
+
+// EllipticK calculates the complete elliptic integral of the first kind for the
+// modulus k using K(k) = π / (2·AGM(1, √(1-k²))).
+func (c *Calculator) EllipticK(k float64) (float64, error) {
+	if math.Abs(k) >= 1 {
+		return 0, errors.New("elliptic modulus must satisfy |k| < 1")
+	}
+
+	agm, err := c.ArithmeticGeometricMean(1, math.Sqrt(1-k*k), 1e-15)
+	if err != nil {
+		return 0, err
+	}
+	return math.Pi / (2 * agm), nil
+}
+
+// Erf calculates the error function of x.
+func (c *Calculator) Erf(x float64) float64 {
+	return math.Erf(x)
+}
+
+// Erfc calculates the complementary error function of x.
+func (c *Calculator) Erfc(x float64) float64 {
+	return math.Erfc(x)
+}
+
+// Erfinv calculates the inverse error function of x for x in (-1, 1).
+func (c *Calculator) Erfinv(x float64) (float64, error) {
+	if x <= -1 || x >= 1 || math.IsNaN(x) {
+		return 0, errors.New("inverse error function is only defined on (-1, 1)")
+	}
+	return math.Erfinv(x), nil
+}
+
+// Beta calculates the beta function B(a,b) = Γ(a)Γ(b)/Γ(a+b), working with
+// log-gamma values to avoid overflow for large arguments.
+func (c *Calculator) Beta(a, b float64) (float64, error) {
+	isPole := func(x float64) bool {
+		return x <= 0 && x == math.Trunc(x)
+	}
+	if isPole(a) || isPole(b) || isPole(a+b) {
+		return 0, errors.New("beta function is undefined at non-positive integers")
+	}
+
+	logA, signA := math.Lgamma(a)
+	logB, signB := math.Lgamma(b)
+	logAB, signAB := math.Lgamma(a + b)
+	return float64(signA*signB*signAB) * math.Exp(logA+logB-logAB), nil
+}
+
+// ChiSquareCDF calculates the cumulative distribution function of the chi-square
+// distribution with df degrees of freedom, P(df/2, x/2), where P is the
+// regularized lower incomplete gamma function.
+func (c *Calculator) ChiSquareCDF(x float64, df int) (float64, error) {
+	if df <= 0 {
+		return 0, errors.New("degrees of freedom must be positive")
+	}
+	if x < 0 {
+		return 0, errors.New("chi-square value cannot be negative")
+	}
+	return regularizedGammaP(float64(df)/2, x/2), nil
+}
+
+// regularizedGammaP calculates the regularized lower incomplete gamma function
+// P(a, x) for a > 0 and x >= 0. It uses the power series when x < a+1 and the
+// Lentz continued fraction for the upper function Q otherwise.
+func regularizedGammaP(a, x float64) float64 {
+	const (
+		epsilon       = 1e-15
+		maxIterations = 1000
+		tiny          = 1e-300
+	)
+	if x == 0 {
+		return 0
+	}
+
+	logGammaA, _ := math.Lgamma(a)
+	prefix := math.Exp(a*math.Log(x) - x - logGammaA)
+
+	if x < a+1 {
+		term := 1 / a
+		sum := term
+		for n := 1; n < maxIterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return sum * prefix
+	}
+
+	b := x + 1 - a
+	cf := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < maxIterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		cf = b + an/cf
+		if math.Abs(cf) < tiny {
+			cf = tiny
+		}
+		d = 1 / d
+		delta := d * cf
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return 1 - prefix*h
+}
+
+// TTestTwoSample calculates Welch's t statistic for the difference between the
+// means of two samples, along with the Welch-Satterthwaite degrees of freedom.
+func (c *Calculator) TTestTwoSample(a, b []float64) (tStat float64, df float64, err error) {
+	if len(a) < 2 || len(b) < 2 {
+		return 0, 0, errors.New("each sample must contain at least two values")
+	}
+
+	varA, varB := variance(a, true), variance(b, true)
+	if varA == 0 || varB == 0 {
+		return 0, 0, errors.New("samples must have non-zero variance")
+	}
+
+	seA := varA / float64(len(a))
+	seB := varB / float64(len(b))
+	tStat = (mean(a) - mean(b)) / math.Sqrt(seA+seB)
+	df = (seA + seB) * (seA + seB) / (seA*seA/float64(len(a)-1) + seB*seB/float64(len(b)-1))
+	return tStat, df, nil
+}
+
+// ConfidenceInterval calculates a two-sided confidence interval for the mean of
+// the values using the sample standard error and a normal approximation.
+func (c *Calculator) ConfidenceInterval(values []float64, confidence float64) (lower, upper float64, err error) {
+	if len(values) < 2 {
+		return 0, 0, errors.New("confidence interval requires at least two values")
+	}
+	if confidence <= 0 || confidence >= 1 {
+		return 0, 0, errors.New("confidence must be between 0 and 1 exclusive")
+	}
+
+	// The two-sided normal critical value is √2·erfinv(confidence).
+	z := math.Sqrt2 * math.Erfinv(confidence)
+	center := mean(values)
+	margin := z * math.Sqrt(variance(values, true)/float64(len(values)))
+	return center - margin, center + margin, nil
+}
+
+// LCG is a 64-bit linear congruential pseudorandom generator using Knuth's MMIX
+// constants. It is deterministic for a given seed and not suitable for cryptography.
+type LCG struct {
+	state uint64
+}
+
+// NewLCG creates a new LCG starting from the given seed.
+func NewLCG(seed uint64) *LCG {
+	return &LCG{state: seed}
+}
+
+// Next advances the generator and returns the next 64-bit value.
+func (g *LCG) Next() uint64 {
+	g.state = g.state*6364136223846793005 + 1442695040888963407
+	return g.state
+}
+
+// NextFloat returns the next value as a float64 in [0, 1). The low bits of an
+// LCG are weak, so only the top 53 bits are used.
+func (g *LCG) NextFloat() float64 {
+	return float64(g.Next()>>11) / (1 << 53)
+}
+
+// ShuffleInts returns a shuffled copy of items using a Fisher-Yates shuffle driven
+// by an LCG seeded with seed. The same seed always yields the same permutation.
+func (c *Calculator) ShuffleInts(items []int, seed uint64) []int {
+	result := make([]int, len(items))
+	copy(result, items)
+
+	generator := NewLCG(seed)
+	for i := len(result) - 1; i > 0; i-- {
+		j := int(generator.NextFloat() * float64(i+1))
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+// SampleNormal returns n samples from a normal distribution with the given mean
+// and standard deviation, generated with the Box-Muller transform from an LCG
+// seeded with seed.
+func (c *Calculator) SampleNormal(mean, stddev float64, seed uint64, n int) ([]float64, error) {
+	if stddev <= 0 {
+		return nil, errors.New("standard deviation must be positive")
+	}
+	if n < 0 {
+		return nil, errors.New("sample count cannot be negative")
+	}
+
+	generator := NewLCG(seed)
+	samples := make([]float64, 0, n)
+	for len(samples) < n {
+		// Use 1-u so the logarithm never sees zero.
+		u1 := 1 - generator.NextFloat()
+		u2 := generator.NextFloat()
+		radius := math.Sqrt(-2 * math.Log(u1))
+		samples = append(samples, mean+stddev*radius*math.Cos(2*math.Pi*u2))
+		if len(samples) < n {
+			samples = append(samples, mean+stddev*radius*math.Sin(2*math.Pi*u2))
+		}
+	}
+	return samples, nil
+}
+
+// EmpiricalCDF calculates the fraction of values that are less than or equal to x.
+func (c *Calculator) EmpiricalCDF(values []float64, x float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, errors.New("values cannot be empty")
+	}
+
+	count := 0
+	for _, v := range values {
+		if v <= x {
+			count++
+		}
+	}
+	return float64(count) / float64(len(values)), nil
+}
+
+// BinaryDivide performs restoring binary long division, returning the quotient as
+// a binary string without leading zeros and the integer remainder.
+func (c *Calculator) BinaryDivide(dividend, divisor int) (quotientBits string, remainder int, err error) {
+	if divisor == 0 {
+		return "", 0, errors.New("division by zero")
+	}
+	if dividend < 0 || divisor < 0 {
+		return "", 0, errors.New("binary division requires non-negative inputs")
+	}
+
+	var quotient strings.Builder
+	for bit := bits.Len(uint(dividend)) - 1; bit >= 0; bit-- {
+		// Bring down the next dividend bit, then subtract the divisor if it fits.
+		remainder = remainder<<1 | (dividend>>bit)&1
+		if remainder >= divisor {
+			remainder -= divisor
+			quotient.WriteByte('1')
+		} else if quotient.Len() > 0 {
+			quotient.WriteByte('0')
+		}
+	}
+
+	if quotient.Len() == 0 {
+		return "0", remainder, nil
+	}
+	return quotient.String(), remainder, nil
+}
+
+// DecomposeFloat splits an IEEE-754 double into its fields: the sign bit (1 for
+// negative), the exponent with the bias of 1023 removed, and the 52-bit fraction
+// without the implicit leading bit. Zero and subnormals have exponent -1023.
+func (c *Calculator) DecomposeFloat(x float64) (sign int, exponent int, mantissa uint64) {
+	raw := math.Float64bits(x)
+	sign = int(raw >> 63)
+	exponent = int((raw>>52)&0x7FF) - 1023
+	mantissa = raw & (1<<52 - 1)
+	return sign, exponent, mantissa
+}
+
+// NextAfter returns the next representable float64 after x in the direction of toward.
+func (c *Calculator) NextAfter(x, toward float64) float64 {
+	return math.Nextafter(x, toward)
+}
+
+// FlushDenormals returns zero (keeping the sign) for subnormal values and x
+// otherwise. Subnormals are magnitudes below the smallest normal double, 2^-1022,
+// which is math.SmallestNonzeroFloat64 scaled by 2^52.
+func (c *Calculator) FlushDenormals(x float64) float64 {
+	const smallestNormal = 0x1p-1022
+	if x != 0 && math.Abs(x) < smallestNormal {
+		return math.Copysign(0, x)
+	}
+	return x
+}
+
+// EvalRational evaluates the rational function P(x)/Q(x), where the coefficients
+// of each polynomial are ordered from the highest degree term to the constant.
+func (c *Calculator) EvalRational(numCoeffs, denCoeffs []float64, x float64) (float64, error) {
+	if len(denCoeffs) == 0 {
+		return 0, errors.New("denominator polynomial cannot be empty")
+	}
+	return divide(horner(numCoeffs, x), horner(denCoeffs, x))
+}
+
+// horner evaluates a polynomial with coefficients ordered from the highest degree
+// term to the constant using Horner's method. An empty polynomial evaluates to 0.
+func horner(coeffs []float64, x float64) float64 {
+	result := 0.0
+	for _, coeff := range coeffs {
+		result = result*x + coeff
+	}
+	return result
+}
+
+// PartitionsIntoKParts counts the partitions of n into exactly k positive parts
+// using the recurrence p(n,k) = p(n-1,k-1) + p(n-k,k).
+func (c *Calculator) PartitionsIntoKParts(n, k int) (int, error) {
+	if n < 0 || k < 0 {
+		return 0, errors.New("partitions are not defined for negative numbers")
+	}
+	if k > n {
+		if n == 0 && k == 0 {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	// table[i][j] holds p(i, j) for i <= n and j <= k.
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, k+1)
+	}
+	table[0][0] = 1
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= k && j <= i; j++ {
+			table[i][j] = table[i-1][j-1] + table[i-j][j]
+		}
+	}
+	return table[n][k], nil
+}
+
+// MovingSum calculates the sum of each window of consecutive values in O(n) by
+// adding the entering value and subtracting the leaving one.
+func (c *Calculator) MovingSum(values []float64, window int) ([]float64, error) {
+	if window <= 0 {
+		return nil, errors.New("window must be positive")
+	}
+	if window > len(values) {
+		return nil, errors.New("window cannot exceed the number of values")
+	}
+
+	sum := 0.0
+	for _, v := range values[:window] {
+		sum += v
+	}
+
+	result := make([]float64, 0, len(values)-window+1)
+	result = append(result, sum)
+	for i := window; i < len(values); i++ {
+		sum += values[i] - values[i-window]
+		result = append(result, sum)
+	}
+	return result, nil
+}
+
+// Autocorrelation calculates the autocorrelation of the values at the given lag,
+// normalized by the total variance so that lag 0 gives 1.
+func (c *Calculator) Autocorrelation(values []float64, lag int) (float64, error) {
+	if len(values) < 2 {
+		return 0, errors.New("autocorrelation requires at least two values")
+	}
+	if lag < 0 || lag >= len(values) {
+		return 0, errors.New("lag must be between 0 and the number of values minus 1")
+	}
+
+	center := mean(values)
+	var numerator, denominator float64
+	for i, v := range values {
+		denominator += (v - center) * (v - center)
+		if i+lag < len(values) {
+			numerator += (v - center) * (values[i+lag] - center)
+		}
+	}
+	if denominator == 0 {
+		return 0, errors.New("autocorrelation is undefined for constant values")
+	}
+	return numerator / denominator, nil
+}
+
+// Convolve calculates the full discrete convolution of a and b, which has
+// len(a)+len(b)-1 elements.
+func (c *Calculator) Convolve(a, b []float64) ([]float64, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return nil, errors.New("convolution requires non-empty inputs")
+	}
+
+	result := make([]float64, len(a)+len(b)-1)
+	for i, x := range a {
+		for j, y := range b {
+			result[i+j] += x * y
+		}
+	}
+	return result, nil
+}
+
+// ExactSum accumulates the values into a big.Float with prec bits of mantissa.
+// The result is exact whenever prec is large enough to span the magnitudes involved.
+// NaN and infinite values are rejected, since big.Float cannot represent NaN.
+func (c *Calculator) ExactSum(values []float64, prec uint) (*big.Float, error) {
+	if prec == 0 {
+		return nil, errors.New("precision must be positive")
+	}
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New("values must be finite")
+		}
+	}
+
+	sum := new(big.Float).SetPrec(prec)
+	term := new(big.Float)
+	for _, v := range values {
+		sum.Add(sum, term.SetFloat64(v))
+	}
+	return sum, nil
+}
+
+// EvalRPN evaluates an expression in reverse Polish notation given as a slice of
+// number and operator tokens. The supported operators are + - * / % and ^, plus
+// the unary negation "neg" and the functions sin, cos, sqrt and log.
+func (c *Calculator) EvalRPN(tokens []string) (float64, error) {
+	if len(tokens) == 0 {
+		return 0, errors.New("empty expression")
+	}
+
+	var stack []float64
+	for _, token := range tokens {
+		switch token {
+		case "neg":
+			if len(stack) < 1 {
+				return 0, errors.New("insufficient operands")
+			}
+			stack[len(stack)-1] = -stack[len(stack)-1]
+		case "sin", "cos", "sqrt", "log":
+			if len(stack) < 1 {
+				return 0, errors.New("insufficient operands")
+			}
+			result, err := c.applyFunction(token, stack[len(stack)-1])
+			if err != nil {
+				return 0, err
+			}
+			stack[len(stack)-1] = result
+		case "+", "-", "*", "/", "%", "^":
+			if len(stack) < 2 {
+				return 0, errors.New("insufficient operands")
+			}
+			a, b := stack[len(stack)-2], stack[len(stack)-1]
+			stack = stack[:len(stack)-2]
+			result, err := c.applyOperator(token, a, b)
+			if err != nil {
+				return 0, err
+			}
+			stack = append(stack, result)
+		default:
+			value, err := strconv.ParseFloat(token, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid token: %s", token)
+			}
+			stack = append(stack, value)
+		}
+	}
+
+	if len(stack) != 1 {
+		return 0, errors.New("too many operands")
+	}
+	return stack[0], nil
+}
+
+// InfixToPostfix converts an infix expression to reverse Polish notation tokens
+// using the shunting-yard algorithm. ^ is right-associative and binds tighter
+// than unary minus, which binds tighter than *, / and %, which bind tighter
+// than + and -. Unary minus appears in the output as "neg", and calls to the
+// built-in functions appear after their argument, as in "16 sqrt". The
+// constants pi and e are replaced by their values. Malformed input is rejected
+// with the same positional errors as Evaluate.
+func (c *Calculator) InfixToPostfix(expr string) ([]string, error) {
+	postfix, err := c.parseExpression(expr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]string, len(postfix))
+	for i, token := range postfix {
+		output[i] = token.Value
+	}
+	return output, nil
+}
+
+// Evaluate parses and evaluates an infix expression such as "(5 + 3) * 2 - 4 / 2".
+// Malformed input is reported with the position of the offending token.
+func (c *Calculator) Evaluate(expr string) (float64, error) {
+	return c.EvalWithVars(expr, nil)
+}
+
+// EvalWithVars evaluates an infix expression in which identifiers are replaced
+// by their values in vars. The constants pi and e and the functions sin, cos,
+// sqrt and log are built in; a variable named pi or e shadows the constant.
+func (c *Calculator) EvalWithVars(expr string, vars map[string]float64) (float64, error) {
+	postfix, err := c.parseExpression(expr, vars)
+	if err != nil {
+		return 0, err
+	}
+	rpn := make([]string, len(postfix))
+	for i, token := range postfix {
+		rpn[i] = token.Value
+	}
+	return c.EvalRPN(rpn)
+}
+
+// parseExpression tokenizes and validates an infix expression and returns its
+// tokens in postfix order, with function calls marked and identifiers replaced
+// by their values in vars or the built-in constants.
+func (c *Calculator) parseExpression(expr string, vars map[string]float64) ([]Token, error) {
+	tokens, err := c.Tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if err := markFunctions(tokens); err != nil {
+		return nil, err
+	}
+	for i, token := range tokens {
+		if token.Kind != TokenIdentifier {
+			continue
+		}
+		value, ok := vars[token.Value]
+		if !ok {
+			value, ok = constants[token.Value]
+		}
+		if !ok {
+			return nil, fmt.Errorf("undefined variable: %s", token.Value)
+		}
+		tokens[i] = Token{Kind: TokenNumber, Value: strconv.FormatFloat(value, 'g', -1, 64), Pos: token.Pos}
+	}
+	if err := validateExpression(tokens, len(expr)); err != nil {
+		return nil, err
+	}
+	return toPostfix(tokens)
+}
+
+// markFunctions changes each identifier followed by "(" into a TokenFunction,
+// returning an error if it does not name a built-in function.
+func markFunctions(tokens []Token) error {
+	for i, token := range tokens {
+		if token.Kind != TokenIdentifier || i+1 >= len(tokens) || tokens[i+1].Kind != TokenLeftParen {
+			continue
+		}
+		if !functions[token.Value] {
+			return fmt.Errorf("unknown function: %s", token.Value)
+		}
+		tokens[i].Kind = TokenFunction
+	}
+	return nil
+}
+
+// validateExpression checks that operands and operators alternate and that
+// parentheses balance, so that errors can point at the offending token. end is
+// the position reported when the expression ends too early.
+func validateExpression(tokens []Token, end int) error {
+	if len(tokens) == 0 {
+		return errors.New("empty expression")
+	}
+
+	var open []int
+	wantOperand := true
+	for _, token := range tokens {
+		switch {
+		case token.Kind == TokenLeftParen || token.Kind == TokenFunction || (token.Kind == TokenOperator && token.Value == "neg"):
+			if !wantOperand {
+				return fmt.Errorf("unexpected %q at position %d", token.Value, token.Pos)
+			}
+			if token.Kind == TokenLeftParen {
+				open = append(open, token.Pos)
+			}
+		case token.Kind == TokenNumber || token.Kind == TokenIdentifier:
+			if !wantOperand {
+				return fmt.Errorf("unexpected %q at position %d", token.Value, token.Pos)
+			}
+			wantOperand = false
+		case token.Kind == TokenRightParen:
+			if len(open) == 0 {
+				return fmt.Errorf("unmatched ')' at position %d", token.Pos)
+			}
+			if wantOperand {
+				return fmt.Errorf("expected operand at position %d", token.Pos)
+			}
+			open = open[:len(open)-1]
+		default:
+			if wantOperand {
+				return fmt.Errorf("expected operand at position %d", token.Pos)
+			}
+			wantOperand = true
+		}
+	}
+
+	if wantOperand {
+		return fmt.Errorf("expected operand at position %d", end)
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("unmatched '(' at position %d", open[len(open)-1])
+	}
+	return nil
+}
+
+// toPostfix reorders infix tokens into postfix order with the shunting-yard
+// algorithm.
+func toPostfix(tokens []Token) ([]Token, error) {
+	var output, operators []Token
+	for _, token := range tokens {
+		switch token.Kind {
+		case TokenLeftParen:
+			operators = append(operators, token)
+		case TokenRightParen:
+			for len(operators) > 0 && operators[len(operators)-1].Kind != TokenLeftParen {
+				output = append(output, operators[len(operators)-1])
+				operators = operators[:len(operators)-1]
+			}
+			if len(operators) == 0 {
+				return nil, errors.New("mismatched parentheses")
+			}
+			operators = operators[:len(operators)-1]
+			if len(operators) > 0 && operators[len(operators)-1].Kind == TokenFunction {
+				output = append(output, operators[len(operators)-1])
+				operators = operators[:len(operators)-1]
+			}
+		case TokenFunction:
+			operators = append(operators, token)
+		case TokenOperator:
+			// A prefix operator has no left operand, so nothing is popped for it.
+			for token.Value != "neg" && len(operators) > 0 {
+				top := operators[len(operators)-1]
+				if top.Kind == TokenLeftParen {
+					break
+				}
+				if precedence[top.Value] < precedence[token.Value] || (precedence[top.Value] == precedence[token.Value] && token.Value == "^") {
+					break
+				}
+				output = append(output, top)
+				operators = operators[:len(operators)-1]
+			}
+			operators = append(operators, token)
+		default:
+			output = append(output, token)
+		}
+	}
+
+	for len(operators) > 0 {
+		top := operators[len(operators)-1]
+		if top.Kind == TokenLeftParen {
+			return nil, errors.New("mismatched parentheses")
+		}
+		output = append(output, top)
+		operators = operators[:len(operators)-1]
+	}
+	return output, nil
+}
+
+// precedence maps each operator to its binding strength.
+var precedence = map[string]int{
+	"+":   1,
+	"-":   1,
+	"*":   2,
+	"/":   2,
+	"%":   2,
+	"neg": 3,
+	"^":   4,
+}
+
+// constants maps the names of built-in expression constants to their values.
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// functions is the set of built-in expression functions.
+var functions = map[string]bool{
+	"sin":  true,
+	"cos":  true,
+	"sqrt": true,
+	"log":  true,
+}
+
+// TokenKind identifies the category of a Token.
+type TokenKind int
+
+const (
+	// TokenNumber is a numeric literal.
+	TokenNumber TokenKind = iota
+	// TokenOperator is an arithmetic operator.
+	TokenOperator
+	// TokenLeftParen is an opening parenthesis.
+	TokenLeftParen
+	// TokenRightParen is a closing parenthesis.
+	TokenRightParen
+	// TokenIdentifier is a name such as a variable.
+	TokenIdentifier
+	// TokenFunction is an identifier called as a function.
+	TokenFunction
+)
+
+// Token is a lexical element of a math expression. Pos is the byte offset of the
+// token in the expression. Unary minus is reported as the operator "neg" to
+// distinguish it from subtraction.
+type Token struct {
+	Kind  TokenKind
+	Value string
+	Pos   int
+}
+
+// Tokenize splits a math expression into number, identifier, operator, and
+// parenthesis tokens, ignoring whitespace. Numbers may contain a decimal point and an
+// exponent, as in 1.5e-3.
+func (c *Calculator) Tokenize(expr string) ([]Token, error) {
+	var tokens []Token
+	for i := 0; i < len(expr); {
+		ch := expr[i]
+		switch {
+		case ch == ' ' || ch == '\t':
+			i++
+		case ch == '(':
+			tokens = append(tokens, Token{Kind: TokenLeftParen, Value: "(", Pos: i})
+			i++
+		case ch == ')':
+			tokens = append(tokens, Token{Kind: TokenRightParen, Value: ")", Pos: i})
+			i++
+		case strings.IndexByte("+-*/%^", ch) >= 0:
+			value := string(ch)
+			// A minus with no operand before it negates what follows.
+			if ch == '-' && expectsOperand(tokens) {
+				value = "neg"
+			}
+			tokens = append(tokens, Token{Kind: TokenOperator, Value: value, Pos: i})
+			i++
+		case isDigit(ch) || ch == '.':
+			start := i
+			for i < len(expr) && (isDigit(expr[i]) || expr[i] == '.') {
+				i++
+			}
+			if i < len(expr) && (expr[i] == 'e' || expr[i] == 'E') {
+				i++
+				if i < len(expr) && (expr[i] == '+' || expr[i] == '-') {
+					i++
+				}
+				for i < len(expr) && isDigit(expr[i]) {
+					i++
+				}
+			}
+			if _, err := strconv.ParseFloat(expr[start:i], 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", expr[start:i], start)
+			}
+			tokens = append(tokens, Token{Kind: TokenNumber, Value: expr[start:i], Pos: start})
+		case isIdentStart(ch):
+			start := i
+			for i < len(expr) && (isIdentStart(expr[i]) || isDigit(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: TokenIdentifier, Value: expr[start:i], Pos: start})
+		default:
+			return nil, fmt.Errorf("invalid character %q at position %d", ch, i)
+		}
+	}
+	return tokens, nil
+}
+
+// expectsOperand reports whether the next token must begin an operand, which is
+// the case at the start of an expression and after an operator or "(".
+func expectsOperand(tokens []Token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	last := tokens[len(tokens)-1]
+	return last.Kind == TokenOperator || last.Kind == TokenLeftParen
+}
+
+// isDigit reports whether ch is an ASCII decimal digit.
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// isIdentStart reports whether ch can begin an identifier.
+func isIdentStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+// MemoryAdd adds value to the memory register (M+).
+func (c *Calculator) MemoryAdd(value float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.memory += value
+}
+
+// MemorySubtract subtracts value from the memory register (M-).
+func (c *Calculator) MemorySubtract(value float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.memory -= value
+}
+
+// MemoryRecall returns the value in the memory register (MR).
+func (c *Calculator) MemoryRecall() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.memory
+}
+
+// MemoryClear resets the memory register to zero (MC).
+func (c *Calculator) MemoryClear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.memory = 0
+}
+
+// Operation is an entry in the calculator's history log.
+type Operation struct {
+	Name   string
+	Inputs []float64
+	Result float64
+	Err    error
+}
+
+// EnableHistory turns recording of operations on or off. History is off by
+// default. Add, Subtract, Multiply, Divide, Power, Sqrt, Factorial and Modulo
+// are recorded when called directly, including calls that return an error;
+// arithmetic done internally by other methods is not.
+func (c *Calculator) EnableHistory(enabled bool) {
+	c.recordHistory.Store(enabled)
+}
+
+// History returns a copy of the recorded operations, oldest first. Changing
+// the returned operations, including their Inputs, does not affect the log.
+func (c *Calculator) History() []Operation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	history := make([]Operation, len(c.history))
+	for i, op := range c.history {
+		op.Inputs = append([]float64(nil), op.Inputs...)
+		history[i] = op
+	}
+	return history
+}
+
+// ClearHistory discards all recorded operations.
+func (c *Calculator) ClearHistory() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.history = nil
+}
+
+// record appends an operation to the history when recording is enabled. When it
+// is disabled no lock is taken.
+func (c *Calculator) record(name string, result float64, err error, inputs ...float64) {
+	if !c.recordHistory.Load() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.history = append(c.history, Operation{Name: name, Inputs: append([]float64(nil), inputs...), Result: result, Err: err})
+}
+
+// applyOperator applies a binary operator. Errors match those of the
+// corresponding Calculator methods, but nothing is recorded in history.
+func (c *Calculator) applyOperator(operator string, a, b float64) (float64, error) {
+	switch operator {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		return divide(a, b)
+	case "%":
+		return modulo(a, b)
+	case "^":
+		return math.Pow(a, b), nil
+	}
+	return 0, fmt.Errorf("unknown operator: %s", operator)
+}
+
+// applyFunction applies a built-in expression function to x.
+func (c *Calculator) applyFunction(name string, x float64) (float64, error) {
+	switch name {
+	case "sin":
+		return c.Sin(x), nil
+	case "cos":
+		return c.Cos(x), nil
+	case "sqrt":
+		return sqrt(x)
+	case "log":
+		return c.Log(x)
+	}
+	return 0, fmt.Errorf("unknown function: %s", name)
+}
+
+// LargestTriangularBelow returns the largest triangular number T(k) = k(k+1)/2
+// that does not exceed n, together with its index k.
+func (c *Calculator) LargestTriangularBelow(n int) (index, value int, err error) {
+	if n < 0 {
+		return 0, 0, errors.New("n cannot be negative")
+	}
+
+	// The float estimate from the quadratic formula can be off by one for large
+	// n, so it is corrected with exact integer comparisons.
+	k := uint64((math.Sqrt(8*float64(n)+1) - 1) / 2)
+	for k > 0 && triangular(k) > uint64(n) {
+		k--
+	}
+	for triangular(k+1) <= uint64(n) {
+		k++
+	}
+	return int(k), int(triangular(k)), nil
+}
+
+// triangular returns k(k+1)/2, which must fit in a uint64.
+func triangular(k uint64) uint64 {
+	hi, lo := bits.Mul64(k, k+1)
+	return hi<<63 | lo>>1
+}
+
+// AddComplex adds two complex numbers.
+func (c *Calculator) AddComplex(a, b complex128) complex128 {
+	return a + b
+}
+
+// SubtractComplex subtracts the second complex number from the first.
+func (c *Calculator) SubtractComplex(a, b complex128) complex128 {
+	return a - b
+}
+
+// MultiplyComplex multiplies two complex numbers.
+func (c *Calculator) MultiplyComplex(a, b complex128) complex128 {
+	return a * b
+}
+
+// DivideComplex divides the first complex number by the second.
+func (c *Calculator) DivideComplex(a, b complex128) (complex128, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+// SqrtComplex calculates the principal square root of a complex number, so
+// negative reals have imaginary roots instead of an error as in Sqrt.
+func (c *Calculator) SqrtComplex(z complex128) complex128 {
+	return cmplx.Sqrt(z)
+}
+
+// FareySequence returns the Farey sequence of order n: the reduced fractions
+// between 0 and 1 with denominators at most n, in ascending order, as
+// {numerator, denominator} pairs.
+func (c *Calculator) FareySequence(n int) ([][2]int, error) {
+	if n < 1 {
+		return nil, errors.New("farey sequence order must be at least 1")
+	}
+
+	// Each term follows from the previous two: for neighbors a/b < c/d, the next
+	// term is (k*c-a)/(k*d-b) with k = (n+b)/d.
+	a, b, num, den := 0, 1, 1, n
+	sequence := [][2]int{{a, b}}
+	for num <= n {
+		sequence = append(sequence, [2]int{num, den})
+		k := (n + b) / den
+		a, b, num, den = num, den, k*num-a, k*den-b
+	}
+	return sequence, nil
+}
+
+// Mean calculates the arithmetic mean of the values.
+func (c *Calculator) Mean(values []float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, errors.New("values cannot be empty")
+	}
+	return mean(values), nil
+}
+
+// Median calculates the median of the values, averaging the two middle values
+// when the length is even. The input slice is not modified.
+func (c *Calculator) Median(values []float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, errors.New("values cannot be empty")
+	}
+	return median(values), nil
+}
+
+// Variance calculates the sample (N-1) or population (N) variance of the values.
+func (c *Calculator) Variance(values []float64, sample bool) (float64, error) {
+	if len(values) == 0 {
+		return 0, errors.New("values cannot be empty")
+	}
+	if sample && len(values) < 2 {
+		return 0, errors.New("sample variance requires at least two values")
+	}
+	return variance(values, sample), nil
+}
+
+// StdDev calculates the sample (N-1) or population (N) standard deviation of
+// the values.
+func (c *Calculator) StdDev(values []float64, sample bool) (float64, error) {
+	v, err := c.Variance(values, sample)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(v), nil
+}
+
+// ModSqrt returns a square root r of n modulo an odd prime p, so that
+// r*r ≡ n (mod p), using the Tonelli-Shanks algorithm. Of the two roots r and
+// p-r, the smaller is returned.
+func (c *Calculator) ModSqrt(n, p int) (int, error) {
+	if p < 3 || p%2 == 0 || !c.IsPrime(p) {
+		return 0, errors.New("modulus must be an odd prime")
+	}
+
+	m := uint64(p)
+	a := uint64((n%p + p) % p)
+	if a == 0 {
+		return 0, nil
+	}
+	// Euler's criterion: a is a quadratic residue iff a^((p-1)/2) ≡ 1.
+	if powMod(a, (m-1)/2, m) != 1 {
+		return 0, errors.New("no square root exists")
+	}
+
+	// Write p-1 = q * 2^s with q odd.
+	q, s := m-1, 0
+	for q%2 == 0 {
+		q /= 2
+		s++
+	}
+
+	// Any quadratic non-residue z generates the 2-power part of the group.
+	z := uint64(2)
+	for powMod(z, (m-1)/2, m) != m-1 {
+		z++
+	}
+
+	cc := powMod(z, q, m)
+	t := powMod(a, q, m)
+	r := powMod(a, (q+1)/2, m)
+	for t != 1 {
+		// Find the least i with t^(2^i) ≡ 1.
+		i, t2 := 0, t
+		for t2 != 1 {
+			t2 = mulMod(t2, t2, m)
+			i++
+		}
+		b := cc
+		for j := 0; j < s-i-1; j++ {
+			b = mulMod(b, b, m)
+		}
+		s = i
+		cc = mulMod(b, b, m)
+		t = mulMod(t, cc, m)
+		r = mulMod(r, b, m)
+	}
+
+	if m-r < r {
+		r = m - r
+	}
+	return int(r), nil
+}
+
+// mulMod returns a*b mod m without overflow.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// powMod returns base^exp mod m by repeated squaring.
+func powMod(base, exp, m uint64) uint64 {
+	result := uint64(1) % m
+	base %= m
+	for exp > 0 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+		exp >>= 1
+	}
+	return result
+}
+
+// trialDivisionLimit is the largest n that IsPrime tests by trial division,
+// beyond which Miller-Rabin is faster.
+const trialDivisionLimit = 1 << 20
+
+// IsPrimeFast tests n for primality with deterministic Miller-Rabin, which is
+// exact for every int and runs in logarithmic time.
+func (c *Calculator) IsPrimeFast(n int) bool {
+	return c.IsProbablePrime(n, 0)
+}
+
+// IsProbablePrime tests n for primality with the Miller-Rabin test. When rounds
+// is zero or negative, a fixed witness set is used that gives an exact answer for
+// every 64-bit n. Otherwise rounds pseudorandom witnesses are drawn from an LCG
+// seeded with n, so the result is reproducible for a given n and rounds.
+func (c *Calculator) IsProbablePrime(n int, rounds int) bool {
+	if n < 2 {
+		return false
+	}
+
+	witnesses := millerRabinWitnesses
+	if rounds > 0 && n > 3 {
+		m := uint64(n)
+		rng := NewLCG(m)
+		witnesses = make([]uint64, rounds)
+		for i := range witnesses {
+			// Scale a random value into [2, n-2] using its high bits.
+			hi, _ := bits.Mul64(rng.Next(), m-3)
+			witnesses[i] = hi + 2
+		}
+	}
+	prime, _ := millerRabin(n, witnesses, nil)
+	return prime
+}
+
+// millerRabin tests n for primality with the Miller-Rabin test for each of the
+// witnesses, after screening out multiples of the first twelve primes. If check
+// is not nil, it is called before each witness, and a non-nil error it returns
+// stops the test and is returned.
+func millerRabin(n int, witnesses []uint64, check func() error) (bool, error) {
+	if n < 2 {
+		return false, nil
+	}
+	for _, p := range millerRabinWitnesses {
+		if n%int(p) == 0 {
+			return n == int(p), nil
+		}
+	}
+
+	// Write n-1 = d * 2^s with d odd.
+	m := uint64(n)
+	s := bits.TrailingZeros64(m - 1)
+	d := (m - 1) >> s
+	for _, a := range witnesses {
+		if check != nil {
+			if err := check(); err != nil {
+				return false, err
+			}
+		}
+		if !isStrongProbablePrime(m, d, s, a) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// millerRabinWitnesses are the first twelve primes, which as Miller-Rabin
+// witnesses correctly classify every n < 3.18e23, and so every 64-bit n.
+var millerRabinWitnesses = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// isStrongProbablePrime reports whether odd n, with n-1 = d * 2^s, passes the
+// Miller-Rabin test for witness a.
+func isStrongProbablePrime(n, d uint64, s int, a uint64) bool {
+	x := powMod(a, d, n)
+	if x == 1 || x == n-1 {
+		return true
+	}
+	for i := 1; i < s; i++ {
+		x = mulMod(x, x, n)
+		if x == n-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// PollardRho returns a non-trivial factor of the composite n using Pollard's rho
+// algorithm with Floyd cycle detection. The factor found need not be prime.
+func (c *Calculator) PollardRho(n int) (int, error) {
+	if n < 4 {
+		return 0, errors.New("n must be at least 4")
+	}
+	if c.IsProbablePrime(n, 0) {
+		return 0, errors.New("n is prime")
+	}
+	if n%2 == 0 {
+		return 2, nil
+	}
+
+	m := uint64(n)
+	// A run that finds only n itself is retried with a different polynomial.
+	for inc := uint64(1); ; inc++ {
+		f := func(x uint64) uint64 {
+			return (mulMod(x, x, m) + inc) % m
+		}
+		x, y, d := uint64(2), uint64(2), 1
+		for d == 1 {
+			x = f(x)
+			y = f(f(y))
+			diff := x - y
+			if x < y {
+				diff = y - x
+			}
+			d = c.BinaryGCD(int(diff), n)
+		}
+		if d != n {
+			return d, nil
+		}
+	}
+}
+
+// PrimeFactorsFast returns the prime factors of n in ascending order with
+// multiplicity, splitting composites with PollardRho so that large factors are
+// found far faster than by trial division.
+func (c *Calculator) PrimeFactorsFast(n int) ([]int, error) {
+	if n < 2 {
+		return nil, errors.New("prime factors are only defined for n >= 2")
+	}
+
+	var factors []int
+	var split func(m int)
+	split = func(m int) {
+		if c.IsProbablePrime(m, 0) {
+			factors = append(factors, m)
+			return
+		}
+		d, _ := c.PollardRho(m)
+		split(d)
+		split(m / d)
+	}
+	split(n)
+	sort.Ints(factors)
+	return factors, nil
+}
+
+// Primorial returns the product of all primes less than or equal to n.
+func (c *Calculator) Primorial(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, errors.New("primorial is not defined for negative numbers")
+	}
+
+	result := big.NewInt(1)
+	factor := new(big.Int)
+	for _, p := range sieve(n) {
+		result.Mul(result, factor.SetInt64(int64(p)))
+	}
+	return result, nil
+}
+
+// sieve returns the primes less than or equal to n in ascending order using the
+// sieve of Eratosthenes.
+func sieve(n int) []int {
+	if n < 2 {
+		return nil
+	}
+
+	composite := make([]bool, n+1)
+	var primes []int
+	for i := 2; i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, i)
+		for j := i * i; j <= n; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// LeastPrimeFactor returns the smallest prime dividing n, which is n itself when
+// n is prime.
+func (c *Calculator) LeastPrimeFactor(n int) (int, error) {
+	if n < 2 {
+		return 0, errors.New("least prime factor is only defined for n >= 2")
+	}
+	if n%2 == 0 {
+		return 2, nil
+	}
+	for p := 3; p <= n/p; p += 2 {
+		if n%p == 0 {
+			return p, nil
+		}
+	}
+	return n, nil
+}
+
+// DigitAt returns the decimal digit of |n| at the given position, where position
+// 0 is the least significant digit.
+func (c *Calculator) DigitAt(n, position int) (int, error) {
+	if position < 0 {
+		return 0, errors.New("position cannot be negative")
+	}
+
+	u := uint(n)
+	if n < 0 {
+		u = -u
+	}
+	for i := 0; i < position; i++ {
+		u /= 10
+		if u == 0 {
+			return 0, errors.New("position exceeds the number of digits")
+		}
+	}
+	return int(u % 10), nil
+}
+
+// PrimeFactors returns the prime factors of n in ascending order with
+// multiplicity, so 12 yields [2 2 3]. It uses trial division; PrimeFactorsFast
+// is quicker when n has large prime factors.
+func (c *Calculator) PrimeFactors(n int) ([]int, error) {
+	if n < 2 {
+		return nil, errors.New("prime factors are only defined for n >= 2")
+	}
+	return primeFactors(n), nil
+}
+
+// PrimeFactorization returns the prime factorization of n as a map from each
+// prime factor to its exponent.
+func (c *Calculator) PrimeFactorization(n int) (map[int]int, error) {
+	factors, err := c.PrimeFactors(n)
+	if err != nil {
+		return nil, err
+	}
+
+	exponents := make(map[int]int)
+	for _, p := range factors {
+		exponents[p]++
+	}
+	return exponents, nil
+}
+
+// DigitCount returns the number of decimal digits in |n|. Zero has one digit.
+func (c *Calculator) DigitCount(n int) int {
+	u := uint(n)
+	if n < 0 {
+		u = -u
+	}
+
+	count := 1
+	for u >= 10 {
+		u /= 10
+		count++
+	}
+	return count
+}
+
+// Fibonacci returns the nth Fibonacci number, with F(0) = 0 and F(1) = 1.
+// F(92) is the largest that fits in an int; use FibonacciBig beyond that.
+func (c *Calculator) Fibonacci(n int) (int, error) {
+	if n < 0 {
+		return 0, errors.New("fibonacci is not defined for negative indices")
+	}
+
+	if n == 0 {
+		return 0, nil
+	}
+
+	a, b := 0, 1
+	for i := 1; i < n; i++ {
+		if a > math.MaxInt-b {
+			return 0, errors.New("fibonacci result overflows int")
+		}
+		a, b = b, a+b
+	}
+	return b, nil
+}
+
+// FibonacciSequence returns the first count Fibonacci numbers, starting at F(0).
+func (c *Calculator) FibonacciSequence(count int) ([]int, error) {
+	if count < 0 {
+		return nil, errors.New("count cannot be negative")
+	}
+	if count > 93 {
+		return nil, errors.New("fibonacci result overflows int")
+	}
+
+	sequence := make([]int, count)
+	for i := range sequence {
+		if i < 2 {
+			sequence[i] = i
+		} else {
+			sequence[i] = sequence[i-1] + sequence[i-2]
+		}
+	}
+	return sequence, nil
+}
+
+// FibonacciBig returns the nth Fibonacci number exactly, without the size limit
+// of Fibonacci.
+func (c *Calculator) FibonacciBig(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, errors.New("fibonacci is not defined for negative indices")
+	}
+
+	a, b := big.NewInt(0), big.NewInt(1)
+	for i := 0; i < n; i++ {
+		a.Add(a, b)
+		a, b = b, a
+	}
+	return a, nil
+}
+
+// BitLength returns the number of bits needed to represent |n|. Zero needs none.
+func (c *Calculator) BitLength(n int) int {
+	u := uint(n)
+	if n < 0 {
+		u = -u
+	}
+	return bits.Len(u)
+}
+
+// Combinations returns the number of ways to choose r of n items without regard
+// to order, C(n, r). It multiplies and divides one term at a time rather than
+// dividing factorials, so it only fails when the result itself overflows.
+func (c *Calculator) Combinations(n, r int) (int, error) {
+	if err := validateChoose(n, r); err != nil {
+		return 0, err
+	}
+	if r > n-r {
+		r = n - r
+	}
+
+	// After step i, result is C(n-r+i, i). Removing the common factor of
+	// result and i first keeps the multiplication exact and as small as possible.
+	result := 1
+	for i := 1; i <= r; i++ {
+		g := c.BinaryGCD(result, i)
+		factor := (n - r + i) / (i / g)
+		hi, lo := bits.Mul(uint(result/g), uint(factor))
+		if hi != 0 || lo > math.MaxInt {
+			return 0, errors.New("combination result overflows int")
+		}
+		result = int(lo)
+	}
+	return result, nil
+}
+
+// Permutations returns the number of ordered arrangements of r of n items,
+// P(n, r) = n!/(n-r)!.
+func (c *Calculator) Permutations(n, r int) (int, error) {
+	if err := validateChoose(n, r); err != nil {
+		return 0, err
+	}
+
+	result := 1
+	for k := n - r + 1; k <= n; k++ {
+		if result > math.MaxInt/k {
+			return 0, errors.New("permutation result overflows int")
+		}
+		result *= k
+	}
+	return result, nil
+}
+
+// CombinationsBig returns C(n, r) exactly, without the size limit of Combinations.
+func (c *Calculator) CombinationsBig(n, r int) (*big.Int, error) {
+	if err := validateChoose(n, r); err != nil {
+		return nil, err
+	}
+	return new(big.Int).Binomial(int64(n), int64(r)), nil
+}
+
+// PermutationsBig returns P(n, r) exactly, without the size limit of Permutations.
+func (c *Calculator) PermutationsBig(n, r int) (*big.Int, error) {
+	if err := validateChoose(n, r); err != nil {
+		return nil, err
+	}
+	if r == 0 {
+		// The empty product is 1, and n-r+1 would overflow for n == math.MaxInt.
+		return big.NewInt(1), nil
+	}
+	return new(big.Int).MulRange(int64(n-r+1), int64(n)), nil
+}
+
+// validateChoose checks the arguments of the combination and permutation methods.
+func validateChoose(n, r int) error {
+	if n < 0 || r < 0 {
+		return errors.New("n and r cannot be negative")
+	}
+	if r > n {
+		return errors.New("r cannot exceed n")
+	}
+	return nil
+}
+
+// RotateLeft rotates the bits of x left by k positions. k is taken modulo 64, so
+// a negative k rotates right.
+func (c *Calculator) RotateLeft(x uint64, k int) uint64 {
+	return bits.RotateLeft64(x, k)
+}
+
+// RotateRight rotates the bits of x right by k positions. k is taken modulo 64,
+// so a negative k rotates left.
+func (c *Calculator) RotateRight(x uint64, k int) uint64 {
+	return bits.RotateLeft64(x, -k)
+}
+
+// GCDSlice calculates the greatest common divisor of all the values. It is 0
+// only when every value is 0.
+func (c *Calculator) GCDSlice(values []int) (int, error) {
+	if len(values) == 0 {
+		return 0, errors.New("values cannot be empty")
+	}
+
+	result := 0
+	for _, v := range values {
+		result = c.BinaryGCD(result, v)
+	}
+	return result, nil
+}
+
+// LCMSlice calculates the least common multiple of all the values, which is 0
+// if any value is 0. It returns an error if the result overflows int.
+func (c *Calculator) LCMSlice(values []int) (int, error) {
+	if len(values) == 0 {
+		return 0, errors.New("values cannot be empty")
+	}
+
+	result := 1
+	for _, v := range values {
+		var err error
+		result, err = c.LCMChecked(result, v)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return result, nil
+}
+
+// ReverseBits reverses the order of the 64 bits of x.
+func (c *Calculator) ReverseBits(x uint64) uint64 {
+	return bits.Reverse64(x)
+}
+
+// Percentage returns value as a percentage of total.
+func (c *Calculator) Percentage(value, total float64) (float64, error) {
+	if total == 0 {
+		return 0, errors.New("total cannot be zero")
+	}
+	return value / total * 100, nil
+}
+
+// PercentOf returns percent percent of value.
+func (c *Calculator) PercentOf(percent, value float64) float64 {
+	return percent / 100 * value
+}
+
+// PercentChange returns the percentage change from oldVal to newVal. The change
+// is measured against |oldVal|, so an increase is always positive.
+func (c *Calculator) PercentChange(oldVal, newVal float64) (float64, error) {
+	if oldVal == 0 {
+		return 0, errors.New("original value cannot be zero")
+	}
+	return (newVal - oldVal) / math.Abs(oldVal) * 100, nil
+}
+
+// ReduceFraction reduces num/den to lowest terms with a positive denominator, so
+// 4/-8 becomes -1/2 and 0/5 becomes 0/1.
+func (c *Calculator) ReduceFraction(num, den int) (int, int, error) {
+	if den == 0 {
+		return 0, 0, errors.New("denominator cannot be zero")
+	}
+
+	g := c.BinaryGCD(num, den)
+	num, den = num/g, den/g
+	if den < 0 {
+		// -math.MinInt does not fit in an int.
+		if num == math.MinInt || den == math.MinInt {
+			return 0, 0, errors.New("fraction overflows int")
+		}
+		num, den = -num, -den
+	}
+	return num, den, nil
+}
+
+// CompareFractions compares n1/d1 with n2/d2 exactly, returning -1, 0 or 1 as the
+// first is less than, equal to or greater than the second.
+func (c *Calculator) CompareFractions(n1, d1, n2, d2 int) (int, error) {
+	if d1 == 0 || d2 == 0 {
+		return 0, errors.New("denominator cannot be zero")
+	}
+
+	// Cross-multiplying by the denominators flips the comparison when their
+	// product is negative.
+	left := new(big.Int).Mul(big.NewInt(int64(n1)), big.NewInt(int64(d2)))
+	right := new(big.Int).Mul(big.NewInt(int64(n2)), big.NewInt(int64(d1)))
+	result := left.Cmp(right)
+	if (d1 < 0) != (d2 < 0) {
+		result = -result
+	}
+	return result, nil
+}
+
+// Mediant returns the mediant (n1+n2)/(d1+d2) of two fractions, reduced to
+// lowest terms. For positive denominators it lies strictly between two unequal
+// fractions.
+func (c *Calculator) Mediant(n1, d1, n2, d2 int) (int, int, error) {
+	if d1 == 0 || d2 == 0 {
+		return 0, 0, errors.New("denominator cannot be zero")
+	}
+	if addOverflows(n1, n2) || addOverflows(d1, d2) {
+		return 0, 0, errors.New("fraction overflows int")
+	}
+	if d1+d2 == 0 {
+		return 0, 0, errors.New("combined denominator cannot be zero")
+	}
+	return c.ReduceFraction(n1+n2, d1+d2)
+}
+
+// addOverflows reports whether a+b overflows an int.
+func addOverflows(a, b int) bool {
+	if b > 0 {
+		return a > math.MaxInt-b
+	}
+	return a < math.MinInt-b
+}
+
+// Sum returns the sum of the values, or 0 for an empty slice.
+func (c *Calculator) Sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// Product returns the product of the values, or 1 for an empty slice.
+func (c *Calculator) Product(values []float64) float64 {
+	product := 1.0
+	for _, v := range values {
+		product *= v
+	}
+	return product
+}
+
+// CumulativeSum returns the running totals of the values, where element i is the
+// sum of values[0] through values[i].
+func (c *Calculator) CumulativeSum(values []float64) []float64 {
+	sums := make([]float64, len(values))
+	total := 0.0
+	for i, v := range values {
+		total += v
+		sums[i] = total
+	}
+	return sums
+}
+
+// SortedSum returns the sum of the values added in order of increasing magnitude,
+// which lets small values accumulate before they meet large ones and so loses
+// less to rounding. The input slice is not modified.
+func (c *Calculator) SortedSum(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return math.Abs(sorted[i]) < math.Abs(sorted[j])
+	})
+	return c.Sum(sorted)
+}
+
+// IsSorted reports whether the values are in ascending (or, if ascending is
+// false, descending) order. Equal neighbors are allowed. As in sort.Float64s,
+// NaN is treated as less than every other value, so an ascending slice may only
+// have NaNs at the start and a descending one only at the end.
+func (c *Calculator) IsSorted(values []float64, ascending bool) bool {
+	less := func(a, b float64) bool {
+		return a < b || (math.IsNaN(a) && !math.IsNaN(b))
+	}
+	for i := 1; i < len(values); i++ {
+		if ascending && less(values[i], values[i-1]) {
+			return false
+		}
+		if !ascending && less(values[i-1], values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Ranks returns the 1-based rank of each value, giving tied values the average
+// of the ranks they span, so [10, 20, 20, 30] ranks as [1, 2.5, 2.5, 4]. The
+// input slice is not modified.
+func (c *Calculator) Ranks(values []float64) ([]float64, error) {
+	if len(values) == 0 {
+		return nil, errors.New("values cannot be empty")
+	}
+
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] < values[order[j]]
+	})
+
+	ranks := make([]float64, len(values))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && values[order[end]] == values[order[start]] {
+			end++
+		}
+		// Positions start..end-1 hold ranks start+1..end, whose mean is this.
+		rank := float64(start+end+1) / 2
+		for _, idx := range order[start:end] {
+			ranks[idx] = rank
+		}
+		start = end
+	}
+	return ranks, nil
+}
+
+// SolveQuadratic returns the real roots of a*x^2 + b*x + k = 0 in ascending
+// order: none when the discriminant is negative, one for a double root and two
+// otherwise. The roots are computed in a form that avoids cancellation when b*b
+// is much larger than 4*a*k.
+func (c *Calculator) SolveQuadratic(a, b, k float64) ([]float64, error) {
+	if a == 0 {
+		return nil, errors.New("coefficient a cannot be zero")
+	}
+
+	discriminant := b*b - 4*a*k
+	if discriminant < 0 {
+		return []float64{}, nil
+	}
+	if discriminant == 0 {
+		return []float64{-b / (2 * a)}, nil
+	}
+
+	root, err := sqrt(discriminant)
+	if err != nil {
+		return nil, err
+	}
+	q := -(b + math.Copysign(root, b)) / 2
+	x1, x2 := q/a, k/q
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+	return []float64{x1, x2}, nil
+}
+
+// SpearmanCorrelation calculates Spearman's rank correlation coefficient, the
+// Pearson correlation of the ranks of x and y. Ties receive averaged ranks.
+func (c *Calculator) SpearmanCorrelation(x, y []float64) (float64, error) {
+	if len(x) != len(y) {
+		return 0, errors.New("x and y must have the same length")
+	}
+	if len(x) < 2 {
+		return 0, errors.New("correlation requires at least two points")
+	}
+
+	rx, err := c.Ranks(x)
+	if err != nil {
+		return 0, err
+	}
+	ry, err := c.Ranks(y)
+	if err != nil {
+		return 0, err
+	}
+
+	mx, my := mean(rx), mean(ry)
+	var sxy, sxx, syy float64
+	for i := range rx {
+		dx, dy := rx[i]-mx, ry[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return 0, errors.New("correlation is undefined for constant values")
+	}
+	return sxy / math.Sqrt(sxx*syy), nil
+}
+
+// FactorialBigContext calculates n! exactly like FactorialBig, but checks ctx
+// between blocks of factors and returns ctx.Err() if it is cancelled.
+func (c *Calculator) FactorialBigContext(ctx context.Context, n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, errors.New("factorial is not defined for negative numbers")
+	}
+
+	const block = 1024
+	result := big.NewInt(1)
+	factors := new(big.Int)
+	for lo := 1; lo <= n; lo += block {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		hi := lo + block - 1
+		if hi > n || hi < lo {
+			hi = n
+		}
+		result.Mul(result, factors.MulRange(int64(lo), int64(hi)))
+	}
+	return result, nil
+}
+
+// IsPrimeContext checks if n is prime like IsPrime, returning ctx.Err() if ctx
+// is cancelled. Large n are tested with Miller-Rabin, checking ctx between
+// witnesses.
+func (c *Calculator) IsPrimeContext(ctx context.Context, n int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if n <= trialDivisionLimit {
+		return c.IsPrime(n), nil
+	}
+	return millerRabin(n, millerRabinWitnesses, ctx.Err)
+}
+
+// CommonRatio returns the ratio term2/term1 between consecutive terms of a
+// geometric sequence.
+func (c *Calculator) CommonRatio(term1, term2 float64) (float64, error) {
+	if term1 == 0 {
+		return 0, errors.New("first term cannot be zero")
+	}
+	return term2 / term1, nil
+}
+
+// CommonDifference returns the difference term2-term1 between consecutive terms
+// of an arithmetic sequence.
+func (c *Calculator) CommonDifference(term1, term2 float64) float64 {
+	return term2 - term1
+}
+
+// DetectSequenceType reports whether the values form an "arithmetic" or a
+// "geometric" sequence, or "none", comparing differences and ratios with a small
+// floating-point tolerance. A constant sequence is reported as arithmetic.
+func (c *Calculator) DetectSequenceType(values []float64) (string, error) {
+	if len(values) < 3 {
+		return "", errors.New("sequence requires at least three values")
+	}
+
+	arithmetic := true
+	difference := c.CommonDifference(values[0], values[1])
+	for i := 2; i < len(values) && arithmetic; i++ {
+		arithmetic = isClose(c.CommonDifference(values[i-1], values[i]), difference)
+	}
+	if arithmetic {
+		return "arithmetic", nil
+	}
+
+	ratio, err := c.CommonRatio(values[0], values[1])
+	if err != nil || ratio == 0 {
+		return "none", nil
+	}
+	for i := 2; i < len(values); i++ {
+		next, err := c.CommonRatio(values[i-1], values[i])
+		if err != nil || !isClose(next, ratio) {
+			return "none", nil
+		}
+	}
+	return "geometric", nil
+}
+
+// isClose reports whether a and b are equal within a relative tolerance of 1e-9,
+// or an absolute tolerance of 1e-12 for values near zero.
+func isClose(a, b float64) bool {
+	return math.Abs(a-b) <= math.Max(1e-9*math.Max(math.Abs(a), math.Abs(b)), 1e-12)
+}
+
 // main function runs the calculator as a standalone application.
 func main() {
 	calc := NewCalculator()
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/big"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -316,6 +322,10 @@ func TestCalculator_LCM(t *testing.T) {
 		{"same number", 8, 8, 8},
 		{"negative numbers", -12, 18, 36},
 		{"with zero", 0, 5, 0},
+		{"both zero", 0, 0, 0},
+		{"large coprime numbers", 1000000, 999999, 999999000000},
+		{"large product with common factor", 3000000000, 6000000000, 6000000000},
+		{"beyond float64 precision", 1<<53 + 1, 3, 1<<53 + 1},
 	}
 
 	for _, tt := range tests {
@@ -324,6 +334,13 @@ func TestCalculator_LCM(t *testing.T) {
 			assert.Equal(t, tt.expected, result)
 		})
 	}
+
+	// LCM agrees with LCMChecked whenever the result fits
+	for _, pair := range [][2]int{{1<<53 + 1, 3}, {1<<60 + 3, 6}, {1 << 61, 6}} {
+		checked, err := calc.LCMChecked(pair[0], pair[1])
+		require.NoError(t, err)
+		assert.Equal(t, checked, calc.LCM(pair[0], pair[1]), "LCM(%d, %d)", pair[0], pair[1])
+	}
 }
 
 func TestCalculator_IsPrime(t *testing.T) {
@@ -348,6 +365,10 @@ func TestCalculator_IsPrime(t *testing.T) {
 		{"zero", 0, false},
 		{"negative", -1, false},
 		{"negative", -5, false},
+		{"large prime", 1000000007, true},
+		{"largest int64 prime", 9223372036854775783, true},
+		{"large semiprime", 1000000007 * 998244353, false},
+		{"large even", 1 << 40, false},
 	}
 
 	for _, tt := range tests {
@@ -485,3 +506,4160 @@ func TestCalculator_EdgeCases(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "division by zero", err.Error())
 }
+
+func TestCalculator_FiniteProduct(t *testing.T) {
+	calc := NewCalculator()
+	identity := func(k int) float64 { return float64(k) }
+
+	tests := []struct {
+		name        string
+		from, to    int
+		expected    float64
+		expectError bool
+	}{
+		{"factorial of 5", 1, 5, 120, false},
+		{"factorial of 10", 1, 10, 3628800, false},
+		{"single term", 7, 7, 7, false},
+		{"range through zero", -2, 2, 0, false},
+		{"from greater than to", 5, 1, 0, true},
+		{"empty range", 1, 0, 0, true},
+		{"ends at max int", math.MaxInt - 1, math.MaxInt, float64(math.MaxInt-1) * float64(math.MaxInt), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.FiniteProduct(identity, tt.from, tt.to)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, "range start must not exceed range end", err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_FiniteSum(t *testing.T) {
+	calc := NewCalculator()
+
+	// Sum of k gives the triangular number
+	result, err := calc.FiniteSum(func(k int) float64 { return float64(k) }, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 5050.0, result)
+
+	// Sum of 1/k gives the harmonic number H(4) = 25/12
+	result, err = calc.FiniteSum(func(k int) float64 { return 1 / float64(k) }, 1, 4)
+	require.NoError(t, err)
+	assert.InDelta(t, 25.0/12.0, result, 1e-12)
+
+	// Single term
+	result, err = calc.FiniteSum(func(k int) float64 { return float64(k * k) }, 3, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 9.0, result)
+
+	// A range ending at math.MaxInt terminates
+	result, err = calc.FiniteSum(func(k int) float64 { return 1 }, math.MaxInt-2, math.MaxInt)
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, result)
+
+	// Test error case
+	_, err = calc.FiniteSum(func(k int) float64 { return float64(k) }, 2, 1)
+	assert.Error(t, err)
+	assert.Equal(t, "range start must not exceed range end", err.Error())
+}
+
+func TestCalculator_Bernoulli(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n        int
+		expected *big.Rat
+	}{
+		{"B0", 0, big.NewRat(1, 1)},
+		{"B1 positive convention", 1, big.NewRat(1, 2)},
+		{"B2", 2, big.NewRat(1, 6)},
+		{"B4", 4, big.NewRat(-1, 30)},
+		{"B12", 12, big.NewRat(-691, 2730)},
+		{"odd B3", 3, big.NewRat(0, 1)},
+		{"odd B7", 7, big.NewRat(0, 1)},
+		{"odd B15", 15, big.NewRat(0, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Bernoulli(tt.n)
+			require.NoError(t, err)
+			assert.Equal(t, 0, tt.expected.Cmp(result), "got %s", result.RatString())
+		})
+	}
+
+	// Test error case
+	_, err := calc.Bernoulli(-1)
+	assert.Error(t, err)
+	assert.Equal(t, "bernoulli number is not defined for negative indices", err.Error())
+}
+
+func TestCalculator_ZetaEven(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n        int
+		expected *big.Rat
+	}{
+		{"zeta(2) = pi^2/6", 2, big.NewRat(1, 6)},
+		{"zeta(4) = pi^4/90", 4, big.NewRat(1, 90)},
+		{"zeta(6) = pi^6/945", 6, big.NewRat(1, 945)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.ZetaEven(tt.n)
+			require.NoError(t, err)
+			assert.Equal(t, 0, tt.expected.Cmp(result), "got %s", result.RatString())
+		})
+	}
+
+	// The coefficient times pi^n should match the known numeric value
+	coefficient, err := calc.ZetaEven(2)
+	require.NoError(t, err)
+	value, _ := coefficient.Float64()
+	assert.InDelta(t, 1.6449340668482264, value*math.Pi*math.Pi, 1e-12)
+
+	// Test error cases
+	for _, n := range []int{3, 0, -2} {
+		_, err := calc.ZetaEven(n)
+		assert.Error(t, err)
+		assert.Equal(t, "exact zeta value requires a positive even integer", err.Error())
+	}
+}
+
+func TestCalculator_Zeta(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		s        float64
+		terms    int
+		expected float64
+		delta    float64
+	}{
+		{"zeta(2)", 2, 1000000, math.Pi * math.Pi / 6, 1e-5},
+		{"zeta(4)", 4, 10000, math.Pow(math.Pi, 4) / 90, 1e-10},
+		{"single term", 3, 1, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Zeta(tt.s, tt.terms)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, tt.delta)
+		})
+	}
+
+	// Test error cases
+	_, err := calc.Zeta(1, 100)
+	assert.Error(t, err)
+	assert.Equal(t, "zeta series diverges for s <= 1", err.Error())
+
+	_, err = calc.Zeta(0.5, 100)
+	assert.Error(t, err)
+	assert.Equal(t, "zeta series diverges for s <= 1", err.Error())
+
+	_, err = calc.Zeta(2, 0)
+	assert.Error(t, err)
+	assert.Equal(t, "number of terms must be at least 1", err.Error())
+}
+
+func TestCalculator_DivisorPairs(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name        string
+		n           int
+		expected    [][2]int
+		expectError bool
+	}{
+		{"composite", 12, [][2]int{{1, 12}, {2, 6}, {3, 4}}, false},
+		{"perfect square", 36, [][2]int{{1, 36}, {2, 18}, {3, 12}, {4, 9}, {6, 6}}, false},
+		{"prime", 13, [][2]int{{1, 13}}, false},
+		{"one", 1, [][2]int{{1, 1}}, false},
+		{"zero", 0, nil, true},
+		{"negative", -4, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.DivisorPairs(tt.n)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, "divisors are only defined for positive integers", err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_SigmaDivisors(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n, k     int
+		expected int
+	}{
+		{"divisor count of 6", 6, 0, 4},
+		{"divisor sum of 6", 6, 1, 12},
+		{"sum of squares of divisors of 6", 6, 2, 50},
+		{"divisor count of perfect square", 36, 0, 9},
+		{"divisor sum of prime", 13, 1, 14},
+		{"one", 1, 3, 1},
+		{"large sum of squares", 1000000, 2, 1388804117611},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.SigmaDivisors(tt.n, tt.k)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	// Test error cases
+	_, err := calc.SigmaDivisors(0, 1)
+	assert.Error(t, err)
+	assert.Equal(t, "divisors are only defined for positive integers", err.Error())
+
+	_, err = calc.SigmaDivisors(6, -1)
+	assert.Error(t, err)
+	assert.Equal(t, "divisor power must be non-negative", err.Error())
+
+	// A single power overflows
+	_, err = calc.SigmaDivisors(1000000, 5)
+	assert.Error(t, err)
+	assert.Equal(t, "divisor sum overflows int", err.Error())
+
+	// Each power fits but their sum does not
+	_, err = calc.SigmaDivisors(3000000000, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "divisor sum overflows int", err.Error())
+}
+
+func TestCalculator_Mobius(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name        string
+		n           int
+		expected    int
+		expectError bool
+	}{
+		{"one", 1, 1, false},
+		{"prime", 2, -1, false},
+		{"two distinct primes", 6, 1, false},
+		{"three distinct primes", 30, -1, false},
+		{"square of prime", 4, 0, false},
+		{"contains square factor", 12, 0, false},
+		{"zero", 0, 0, true},
+		{"negative", -6, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Mobius(tt.n)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, "mobius function is only defined for positive integers", err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_PascalRow(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n        int
+		expected []int
+	}{
+		{"row 0", 0, []int{1}},
+		{"row 1", 1, []int{1, 1}},
+		{"row 4", 4, []int{1, 4, 6, 4, 1}},
+		{"row 6", 6, []int{1, 6, 15, 20, 15, 6, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.PascalRow(tt.n)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	// Rows are symmetric
+	row, err := calc.PascalRow(30)
+	require.NoError(t, err)
+	for k := range row {
+		assert.Equal(t, row[k], row[len(row)-1-k])
+	}
+	assert.Equal(t, 155117520, row[15])
+
+	// Test error cases
+	_, err = calc.PascalRow(-1)
+	assert.Error(t, err)
+	assert.Equal(t, "pascal row index must be non-negative", err.Error())
+
+	for _, n := range []int{maxPascalRow + 1, 100, math.MaxInt} {
+		_, err = calc.PascalRow(n)
+		assert.Error(t, err)
+		assert.Equal(t, "pascal row overflows int", err.Error(), "n = %d", n)
+	}
+
+	if math.MaxInt == math.MaxInt64 {
+		row, err = calc.PascalRow(maxPascalRow)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7219428434016265740), int64(row[33]))
+	}
+}
+
+func TestPSquareEstimator(t *testing.T) {
+	// Feed a permutation of 0..10006 so the exact percentiles are known
+	const size = 10007
+	for _, p := range []float64{0.5, 0.9, 0.99} {
+		estimator, err := NewPSquare(p)
+		require.NoError(t, err)
+		for i := 0; i < size; i++ {
+			estimator.Push(float64(i * 7919 % size))
+		}
+		exact := p * (size - 1)
+		assert.InDelta(t, exact, estimator.Estimate(), 0.01*size, "percentile %v", p)
+	}
+
+	// Before five samples the exact percentile of the stored samples is returned
+	estimator, err := NewPSquare(0.5)
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(estimator.Estimate()))
+	estimator.Push(3)
+	assert.Equal(t, 3.0, estimator.Estimate())
+	estimator.Push(1)
+	estimator.Push(2)
+	assert.Equal(t, 2.0, estimator.Estimate())
+	estimator.Push(10)
+	assert.Equal(t, 2.5, estimator.Estimate())
+
+	// Test error cases
+	for _, p := range []float64{0, 1, -0.5, 1.5} {
+		_, err := NewPSquare(p)
+		assert.Error(t, err)
+		assert.Equal(t, "percentile must be between 0 and 1 exclusive", err.Error())
+	}
+}
+
+func TestCalculator_WrapRange(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name        string
+		value       float64
+		lo, hi      float64
+		expected    float64
+		expectError bool
+	}{
+		{"above range", 370, 0, 360, 10, false},
+		{"several periods above", 1090, 0, 360, 10, false},
+		{"below range", -10, 0, 360, 350, false},
+		{"inside range", 45, 0, 360, 45, false},
+		{"upper bound wraps to lower", 360, 0, 360, 0, false},
+		{"symmetric range", 190, -180, 180, -170, false},
+		{"fractional", 7.5, 0, 2.5, 0, false},
+		{"equal bounds", 5, 1, 1, 0, true},
+		{"inverted bounds", 5, 10, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.WrapRange(tt.value, tt.lo, tt.hi)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, "lower bound must be less than upper bound", err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-9)
+			}
+		})
+	}
+}
+
+func TestCalculator_Log2(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name        string
+		number      float64
+		expected    float64
+		expectError bool
+	}{
+		{"power of two", 8, 3, false},
+		{"one", 1, 0, false},
+		{"fractional input", 0.25, -2, false},
+		{"non power of two", 10, 3.321928094887362, false},
+		{"zero", 0, 0, true},
+		{"negative number", -8, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Log2(tt.number)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, "logarithm is not defined for non-positive numbers", err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_RationalPower(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		base     float64
+		num, den int
+		expected float64
+	}{
+		{"square root", 16, 1, 2, 4},
+		{"fractional exponent", 8, 2, 3, 4},
+		{"fifth root", 243, 1, 5, 3},
+		{"negative exponent", 4, -1, 2, 0.5},
+		{"negative base odd root", -8, 2, 3, 4},
+		{"negative base odd root odd power", -27, 1, 3, -3},
+		{"negative denominator", 8, 1, -3, 0.5},
+		{"reducible exponent", -8, 2, 6, 2},
+		{"reducible exponent even denominator", -4, 2, 4, 2},
+		{"negative base odd power odd root", -32, 3, 5, -8},
+		{"zero base", 0, 1, 2, 0},
+		{"integer exponent", 3, 4, 1, 81},
+		{"irrational result", 2, 1, 2, math.Sqrt2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.RationalPower(tt.base, tt.num, tt.den)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	// Test error cases
+	_, err := calc.RationalPower(8, 1, 0)
+	assert.Error(t, err)
+	assert.Equal(t, "exponent denominator cannot be zero", err.Error())
+
+	_, err = calc.RationalPower(-16, 1, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "cannot calculate even root of negative number", err.Error())
+
+	_, err = calc.RationalPower(-16, 3, 6)
+	assert.Error(t, err)
+	assert.Equal(t, "cannot calculate even root of negative number", err.Error())
+
+	_, err = calc.RationalPower(0, -1, 1)
+	assert.Error(t, err)
+	assert.Equal(t, "division by zero", err.Error())
+
+	_, err = calc.RationalPower(0, 1, -2)
+	assert.Error(t, err)
+	assert.Equal(t, "division by zero", err.Error())
+}
+
+func TestCalculator_CumulativeMaxMin(t *testing.T) {
+	calc := NewCalculator()
+	values := []float64{3, 1, 4, 1, 5, -9, 2, 6}
+
+	// Test CumulativeMax
+	maxima := calc.CumulativeMax(values)
+	assert.Equal(t, []float64{3, 3, 4, 4, 5, 5, 5, 6}, maxima)
+	for i := 1; i < len(maxima); i++ {
+		assert.GreaterOrEqual(t, maxima[i], maxima[i-1])
+	}
+
+	// Test CumulativeMin
+	minima := calc.CumulativeMin(values)
+	assert.Equal(t, []float64{3, 1, 1, 1, 1, -9, -9, -9}, minima)
+	for i := 1; i < len(minima); i++ {
+		assert.LessOrEqual(t, minima[i], minima[i-1])
+	}
+
+	// Test empty input
+	assert.Equal(t, []float64{}, calc.CumulativeMax([]float64{}))
+	assert.Equal(t, []float64{}, calc.CumulativeMin(nil))
+}
+
+func TestCalculator_CoinChange(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		amount        int
+		denominations []int
+		expected      int
+		expectedError string
+	}{
+		{"classic example", 4, []int{1, 2, 3}, 4, ""},
+		{"zero amount", 0, []int{1, 2}, 1, ""},
+		{"us coins", 100, []int{1, 5, 10, 25, 50, 100}, 293, ""},
+		{"impossible amount", 3, []int{2}, 0, ""},
+		{"negative amount", -1, []int{1}, 0, "amount cannot be negative"},
+		{"empty denominations", 5, []int{}, 0, "denominations cannot be empty"},
+		{"zero denomination", 5, []int{1, 0}, 0, "denominations must be positive"},
+		{"negative denomination", 5, []int{-2}, 0, "denominations must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.CoinChange(tt.amount, tt.denominations)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+
+	// With every part size available, the count is the partition number p(n)
+	parts := make([]int, 1000)
+	for i := range parts {
+		parts[i] = i + 1
+	}
+	if math.MaxInt == math.MaxInt64 {
+		result, err := calc.CoinChange(400, parts[:400])
+		require.NoError(t, err)
+		assert.Equal(t, int64(6727090051741041926), int64(result))
+	}
+
+	_, err := calc.CoinChange(1000, parts)
+	assert.Error(t, err)
+	assert.Equal(t, "number of ways overflows int", err.Error())
+}
+
+func TestCalculator_MinCoins(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		amount        int
+		denominations []int
+		expected      int
+		expectedError string
+	}{
+		{"classic example", 11, []int{1, 2, 5}, 3, ""},
+		{"greedy is not optimal", 6, []int{1, 3, 4}, 2, ""},
+		{"zero amount", 0, []int{1, 2, 5}, 0, ""},
+		{"single coin", 5, []int{1, 2, 5}, 1, ""},
+		{"impossible amount", 3, []int{2}, 0, "cannot make amount with given denominations"},
+		{"negative amount", -1, []int{1}, 0, "amount cannot be negative"},
+		{"empty denominations", 5, nil, 0, "denominations cannot be empty"},
+		{"zero denomination", 5, []int{0}, 0, "denominations must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.MinCoins(tt.amount, tt.denominations)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_IsLeapYear(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		year     int
+		expected bool
+	}{
+		{"century divisible by 400", 2000, true},
+		{"divisible by 4", 2024, true},
+		{"century not divisible by 400", 1900, false},
+		{"common year", 2023, false},
+		{"year zero (1 BCE)", 0, true},
+		{"year -4 (5 BCE)", -4, true},
+		{"year -1 (2 BCE)", -1, false},
+		{"year -100 (101 BCE)", -100, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calc.IsLeapYear(tt.year))
+		})
+	}
+}
+
+func TestCalculator_DayOfWeek(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		year          int
+		month, day    int
+		expected      int
+		expectedError string
+	}{
+		{"known Monday", 2024, 1, 1, 1, ""},
+		{"known Sunday", 2023, 12, 31, 0, ""},
+		{"leap day", 2024, 2, 29, 4, ""},
+		{"known Saturday", 2000, 1, 1, 6, ""},
+		{"moon landing (Sunday)", 1969, 7, 20, 0, ""},
+		{"month zero", 2024, 0, 1, 0, "invalid month"},
+		{"month thirteen", 2024, 13, 1, 0, "invalid month"},
+		{"day zero", 2024, 1, 0, 0, "invalid day"},
+		{"April 31st", 2024, 4, 31, 0, "invalid day"},
+		{"leap day in common year", 2023, 2, 29, 0, "invalid day"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.DayOfWeek(tt.year, tt.month, tt.day)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_DaysBetween(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		y1, m1, d1    int
+		y2, m2, d2    int
+		expected      int
+		expectedError string
+	}{
+		{"one day apart", 2024, 3, 14, 2024, 3, 15, 1, ""},
+		{"same day", 2024, 3, 14, 2024, 3, 14, 0, ""},
+		{"across leap February", 2024, 2, 28, 2024, 3, 1, 2, ""},
+		{"across common February", 2023, 2, 28, 2023, 3, 1, 1, ""},
+		{"across year boundary", 2023, 12, 31, 2024, 1, 1, 1, ""},
+		{"full leap year", 2024, 1, 1, 2025, 1, 1, 366, ""},
+		{"reversed order", 2024, 1, 1, 2023, 1, 1, -365, ""},
+		{"unix epoch to y2k", 1970, 1, 1, 2000, 1, 1, 10957, ""},
+		{"leap year -4800", -4801, 3, 1, -4800, 3, 1, 366, ""},
+		{"400-year cycle across -4800", -5000, 1, 1, -4600, 1, 1, 146097, ""},
+		{"400-year cycles before -4800", -4801, 1, 1, -401, 1, 1, 11 * 146097, ""},
+		{"invalid first date", 2023, 2, 29, 2024, 1, 1, 0, "invalid day"},
+		{"invalid second date", 2024, 1, 1, 2024, 13, 1, 0, "invalid month"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.DaysBetween(tt.y1, tt.m1, tt.d1, tt.y2, tt.m2, tt.d2)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_WeightedGeometricMean(t *testing.T) {
+	calc := NewCalculator()
+
+	// Equal weights give the unweighted geometric mean (2*8)^(1/2) = 4
+	result, err := calc.WeightedGeometricMean([]float64{2, 8}, []float64{1, 1})
+	require.NoError(t, err)
+	assert.InDelta(t, 4.0, result, 1e-12)
+
+	result, err = calc.WeightedGeometricMean([]float64{1, 3, 9}, []float64{0.5, 0.5, 0.5})
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, result, 1e-12)
+
+	// Hand-computed weighted case: (2^1 * 16^3)^(1/4) = 2^(13/4)
+	result, err = calc.WeightedGeometricMean([]float64{2, 16}, []float64{1, 3})
+	require.NoError(t, err)
+	assert.InDelta(t, math.Pow(2, 13.0/4.0), result, 1e-12)
+
+	// Zero weights ignore their values
+	result, err = calc.WeightedGeometricMean([]float64{5, 100}, []float64{2, 0})
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0, result, 1e-12)
+
+	// Test error cases
+	tests := []struct {
+		name          string
+		values        []float64
+		weights       []float64
+		expectedError string
+	}{
+		{"length mismatch", []float64{1, 2}, []float64{1}, "values and weights must have the same length"},
+		{"zero value", []float64{0, 2}, []float64{1, 1}, "geometric mean requires positive values"},
+		{"negative value", []float64{-1, 2}, []float64{1, 1}, "geometric mean requires positive values"},
+		{"negative weight", []float64{1, 2}, []float64{-1, 2}, "weights cannot be negative"},
+		{"zero total weight", []float64{1, 2}, []float64{0, 0}, "total weight must be positive"},
+		{"empty input", []float64{}, []float64{}, "total weight must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.WeightedGeometricMean(tt.values, tt.weights)
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedError, err.Error())
+		})
+	}
+}
+
+func TestCalculator_Entropy(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		probabilities []float64
+		base          float64
+		expected      float64
+	}{
+		{"fair coin in bits", []float64{0.5, 0.5}, 2, 1},
+		{"uniform over 8 in bits", []float64{0.125, 0.125, 0.125, 0.125, 0.125, 0.125, 0.125, 0.125}, 2, 3},
+		{"uniform over 4 in nats", []float64{0.25, 0.25, 0.25, 0.25}, math.E, math.Log(4)},
+		{"deterministic", []float64{0, 1, 0}, 2, 0},
+		{"biased coin", []float64{0.25, 0.75}, 2, 0.8112781244591328},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Entropy(tt.probabilities, tt.base)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-12)
+		})
+	}
+
+	// Test error cases
+	_, err := calc.Entropy([]float64{-0.5, 1.5}, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "probabilities cannot be negative", err.Error())
+
+	_, err = calc.Entropy([]float64{0.5, 0.4}, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "probabilities must sum to 1", err.Error())
+
+	_, err = calc.Entropy([]float64{}, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "probabilities must sum to 1", err.Error())
+
+	_, err = calc.Entropy([]float64{0.5, 0.5}, 1)
+	assert.Error(t, err)
+	assert.Equal(t, "logarithm base must be greater than 1", err.Error())
+}
+
+func TestCalculator_KLDivergence(t *testing.T) {
+	calc := NewCalculator()
+
+	// Identical distributions have zero divergence
+	result, err := calc.KLDivergence([]float64{0.2, 0.3, 0.5}, []float64{0.2, 0.3, 0.5}, 2)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0, result, 1e-12)
+
+	// Known pair: 0.5*log2(0.5/0.25) + 0.5*log2(0.5/0.75)
+	result, err = calc.KLDivergence([]float64{0.5, 0.5}, []float64{0.25, 0.75}, 2)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.20751874963942190, result, 1e-12)
+
+	// A zero in p with a non-zero q contributes nothing
+	result, err = calc.KLDivergence([]float64{1, 0}, []float64{0.5, 0.5}, 2)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, result, 1e-12)
+
+	// Test error cases
+	tests := []struct {
+		name          string
+		p, q          []float64
+		base          float64
+		expectedError string
+	}{
+		{"length mismatch", []float64{0.5, 0.5}, []float64{1}, 2, "distributions must have the same length"},
+		{"negative value", []float64{1.5, -0.5}, []float64{0.5, 0.5}, 2, "probabilities cannot be negative"},
+		{"p does not sum to 1", []float64{0.5, 0.2}, []float64{0.5, 0.5}, 2, "probabilities must sum to 1"},
+		{"q does not sum to 1", []float64{0.5, 0.5}, []float64{0.9, 0.9}, 2, "probabilities must sum to 1"},
+		{"zero in q where p is non-zero", []float64{0.5, 0.5}, []float64{1, 0}, 2, "q must be non-zero wherever p is non-zero"},
+		{"invalid base", []float64{0.5, 0.5}, []float64{0.5, 0.5}, 0.5, "logarithm base must be greater than 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.KLDivergence(tt.p, tt.q, tt.base)
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedError, err.Error())
+		})
+	}
+}
+
+func TestCalculator_CrossEntropy(t *testing.T) {
+	calc := NewCalculator()
+
+	// Cross-entropy of a distribution with itself is its entropy
+	p := []float64{0.1, 0.2, 0.3, 0.4}
+	crossEntropy, err := calc.CrossEntropy(p, p, 2)
+	require.NoError(t, err)
+	entropy, err := calc.Entropy(p, 2)
+	require.NoError(t, err)
+	assert.InDelta(t, entropy, crossEntropy, 1e-12)
+
+	// Known pair: -(0.5*log2(0.25) + 0.5*log2(0.75))
+	result, err := calc.CrossEntropy([]float64{0.5, 0.5}, []float64{0.25, 0.75}, 2)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.2075187496394219, result, 1e-12)
+
+	// Cross-entropy equals entropy plus KL divergence
+	q := []float64{0.25, 0.25, 0.25, 0.25}
+	crossEntropy, err = calc.CrossEntropy(p, q, math.E)
+	require.NoError(t, err)
+	entropy, err = calc.Entropy(p, math.E)
+	require.NoError(t, err)
+	divergence, err := calc.KLDivergence(p, q, math.E)
+	require.NoError(t, err)
+	assert.InDelta(t, entropy+divergence, crossEntropy, 1e-12)
+
+	// Test error cases
+	_, err = calc.CrossEntropy([]float64{0.5, 0.5}, []float64{1}, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "distributions must have the same length", err.Error())
+
+	_, err = calc.CrossEntropy([]float64{0.5, 0.6}, []float64{0.5, 0.5}, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "probabilities must sum to 1", err.Error())
+
+	_, err = calc.CrossEntropy([]float64{0.5, 0.5}, []float64{0, 1}, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "q must be non-zero wherever p is non-zero", err.Error())
+}
+
+func TestCalculator_MeanAbsoluteDeviation(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		values   []float64
+		expected float64
+	}{
+		{"small dataset", []float64{2, 4, 6, 8}, 2},
+		{"asymmetric dataset", []float64{1, 2, 3, 4, 10}, 2.4},
+		{"single element", []float64{42}, 0},
+		{"constant values", []float64{3, 3, 3}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.MeanAbsoluteDeviation(tt.values)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-12)
+		})
+	}
+
+	// Test error case
+	_, err := calc.MeanAbsoluteDeviation([]float64{})
+	assert.Error(t, err)
+	assert.Equal(t, "values cannot be empty", err.Error())
+}
+
+func TestCalculator_MedianAbsoluteDeviation(t *testing.T) {
+	calc := NewCalculator()
+
+	// Outliers barely move the median absolute deviation
+	clean := []float64{1, 2, 3, 4, 5, 6, 7}
+	withOutliers := []float64{1, 2, 3, 4, 5, 6, 1000}
+	cleanMAD, err := calc.MedianAbsoluteDeviation(clean)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, cleanMAD)
+	outlierMAD, err := calc.MedianAbsoluteDeviation(withOutliers)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, outlierMAD)
+
+	// Even-length dataset: median 2.5, deviations [1.5 1.5 0.5 0.5]
+	result, err := calc.MedianAbsoluteDeviation([]float64{4, 1, 3, 2})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, result)
+
+	// Uniform dataset has zero spread
+	result, err = calc.MedianAbsoluteDeviation([]float64{5, 5, 5, 5})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, result)
+
+	// The input is not mutated
+	values := []float64{9, 1, 5, 3}
+	_, err = calc.MedianAbsoluteDeviation(values)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{9, 1, 5, 3}, values)
+
+	// Test error case
+	_, err = calc.MedianAbsoluteDeviation(nil)
+	assert.Error(t, err)
+	assert.Equal(t, "values cannot be empty", err.Error())
+}
+
+func TestCalculator_SlidingVariance(t *testing.T) {
+	calc := NewCalculator()
+	values := []float64{4, 8, 15, 16, 23, 42, 1e6, 3, 7, 7, 7, 2.5}
+
+	for _, window := range []int{2, 3, 5, len(values)} {
+		for _, sample := range []bool{true, false} {
+			result, err := calc.SlidingVariance(values, window, sample)
+			require.NoError(t, err)
+			require.Len(t, result, len(values)-window+1)
+			for i := range result {
+				expected := variance(values[i:i+window], sample)
+				assert.InDelta(t, expected, result[i], 1e-6*(1+expected), "window %d at %d", window, i)
+			}
+		}
+	}
+
+	// Constant windows have zero variance
+	result, err := calc.SlidingVariance([]float64{1, 2, 2, 2, 2}, 3, true)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0, result[2], 1e-12)
+
+	// Test error cases
+	_, err = calc.SlidingVariance(values, 1, true)
+	assert.Error(t, err)
+	assert.Equal(t, "window must be greater than 1", err.Error())
+
+	_, err = calc.SlidingVariance(values, len(values)+1, true)
+	assert.Error(t, err)
+	assert.Equal(t, "window cannot exceed the number of values", err.Error())
+}
+
+func BenchmarkCalculator_SlidingVariance(b *testing.B) {
+	calc := NewCalculator()
+	values := make([]float64, 10000)
+	for i := range values {
+		values[i] = math.Sin(float64(i))
+	}
+
+	b.Run("incremental", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = calc.SlidingVariance(values, 500, true)
+		}
+	})
+	b.Run("recompute", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for start := 0; start+500 <= len(values); start++ {
+				_ = variance(values[start:start+500], true)
+			}
+		}
+	})
+}
+
+func TestCalculator_BinarySearchFloat(t *testing.T) {
+	calc := NewCalculator()
+	belowTwo := func(x float64) bool { return x*x <= 2 }
+
+	// Locate sqrt(2) as the point where x² crosses 2
+	for _, tol := range []float64{1e-3, 1e-9, 1e-15} {
+		result, err := calc.BinarySearchFloat(0, 2, belowTwo, tol)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, result, math.Sqrt2)
+		assert.InDelta(t, math.Sqrt2, result, tol)
+	}
+
+	// Predicate satisfied across the whole range returns the upper bound
+	result, err := calc.BinarySearchFloat(0, 1, belowTwo, 1e-9)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, result)
+
+	// Degenerate range
+	result, err = calc.BinarySearchFloat(1, 1, belowTwo, 1e-9)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, result)
+
+	// Test error cases
+	_, err = calc.BinarySearchFloat(2, 0, belowTwo, 1e-9)
+	assert.Error(t, err)
+	assert.Equal(t, "lower bound must not exceed upper bound", err.Error())
+
+	_, err = calc.BinarySearchFloat(0, 2, belowTwo, 0)
+	assert.Error(t, err)
+	assert.Equal(t, "tolerance must be positive", err.Error())
+
+	_, err = calc.BinarySearchFloat(3, 4, belowTwo, 1e-9)
+	assert.Error(t, err)
+	assert.Equal(t, "predicate is not satisfied at the lower bound", err.Error())
+}
+
+func TestCalculator_ArithmeticGeometricMean(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		a, b     float64
+		expected float64
+	}{
+		{"equal inputs", 1, 1, 1},
+		{"Gauss's constant reciprocal", 1, math.Sqrt2, 1.1981402347355922},
+		{"order does not matter", math.Sqrt2, 1, 1.1981402347355922},
+		{"zero input", 0, 5, 0},
+		{"larger inputs", 24, 6, 13.458171481725614},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.ArithmeticGeometricMean(tt.a, tt.b, 1e-15)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-12)
+		})
+	}
+
+	// Test error cases
+	_, err := calc.ArithmeticGeometricMean(-1, 2, 1e-12)
+	assert.Error(t, err)
+	assert.Equal(t, "arithmetic-geometric mean requires non-negative inputs", err.Error())
+
+	_, err = calc.ArithmeticGeometricMean(1, 2, 0)
+	assert.Error(t, err)
+	assert.Equal(t, "tolerance must be positive", err.Error())
+}
+
+func TestCalculator_EllipticK(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		k        float64
+		expected float64
+	}{
+		{"zero modulus", 0, math.Pi / 2},
+		{"mid-range modulus", 0.5, 1.6857503548125961},
+		{"negative modulus", -0.5, 1.6857503548125961},
+		{"modulus sin(45°)", math.Sqrt2 / 2, 1.8540746773013719},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.EllipticK(tt.k)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-12)
+		})
+	}
+
+	// Test error cases
+	for _, k := range []float64{1, -1, 1.5} {
+		_, err := calc.EllipticK(k)
+		assert.Error(t, err)
+		assert.Equal(t, "elliptic modulus must satisfy |k| < 1", err.Error())
+	}
+}
+
+func TestCalculator_ErrorFunction(t *testing.T) {
+	calc := NewCalculator()
+
+	// Test known values
+	assert.Equal(t, 0.0, calc.Erf(0))
+	assert.Equal(t, 1.0, calc.Erfc(0))
+	assert.InDelta(t, 0.8427007929497149, calc.Erf(1), 1e-12)
+	assert.InDelta(t, 1.0, calc.Erf(6), 1e-12)
+
+	for _, x := range []float64{-3, -1, -0.25, 0, 0.5, 1, 2.5} {
+		// Erf and Erfc are complementary
+		assert.InDelta(t, 1.0, calc.Erf(x)+calc.Erfc(x), 1e-12)
+
+		// Erf has odd symmetry
+		assert.Equal(t, -calc.Erf(x), calc.Erf(-x))
+	}
+}
+
+func TestCalculator_Erfinv(t *testing.T) {
+	calc := NewCalculator()
+
+	result, err := calc.Erfinv(0)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, result)
+
+	// Erfinv inverts Erf
+	for _, x := range []float64{-0.99, -0.5, 0.1, 0.5, 0.8427007929497149, 0.999} {
+		result, err := calc.Erfinv(x)
+		require.NoError(t, err)
+		assert.InDelta(t, x, calc.Erf(result), 1e-12)
+	}
+
+	// Test error cases
+	for _, x := range []float64{1, -1, 1.5, math.NaN()} {
+		_, err := calc.Erfinv(x)
+		assert.Error(t, err)
+		assert.Equal(t, "inverse error function is only defined on (-1, 1)", err.Error())
+	}
+}
+
+func TestCalculator_Beta(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		a, b     float64
+		expected float64
+	}{
+		{"unit arguments", 1, 1, 1},
+		{"integer arguments", 2, 3, 1.0 / 12.0},
+		{"symmetric", 3, 2, 1.0 / 12.0},
+		{"half arguments", 0.5, 0.5, math.Pi},
+		{"negative non-integer argument", -0.5, 2, -4},
+		{"large arguments", 100, 100, 2.2087606931995024e-61},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Beta(tt.a, tt.b)
+			require.NoError(t, err)
+			assert.InEpsilon(t, tt.expected, result, 1e-9)
+		})
+	}
+
+	// Test error cases
+	for _, args := range [][2]float64{{0, 1}, {1, -2}, {-1.5, -0.5}} {
+		_, err := calc.Beta(args[0], args[1])
+		assert.Error(t, err)
+		assert.Equal(t, "beta function is undefined at non-positive integers", err.Error())
+	}
+}
+
+func TestCalculator_ChiSquareCDF(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		x        float64
+		df       int
+		expected float64
+	}{
+		{"zero", 0, 3, 0},
+		{"95th percentile df=1", 3.841458820694124, 1, 0.95},
+		{"95th percentile df=2", 5.991464547107979, 2, 0.95},
+		{"99th percentile df=5", 15.08627246938899, 5, 0.99},
+		{"95th percentile df=10", 18.307038053275146, 10, 0.95},
+		{"5th percentile df=10", 3.940299136119290, 10, 0.05},
+		{"closed form df=2", 1, 2, 1 - math.Exp(-0.5)},
+		{"far tail", 200, 10, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.ChiSquareCDF(tt.x, tt.df)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-9)
+		})
+	}
+
+	// Test error cases
+	_, err := calc.ChiSquareCDF(1, 0)
+	assert.Error(t, err)
+	assert.Equal(t, "degrees of freedom must be positive", err.Error())
+
+	_, err = calc.ChiSquareCDF(-1, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "chi-square value cannot be negative", err.Error())
+}
+
+func TestCalculator_TTestTwoSample(t *testing.T) {
+	calc := NewCalculator()
+
+	// Hand-computed: means 3 and 6, sample variances 2.5 and 10
+	a := []float64{1, 2, 3, 4, 5}
+	b := []float64{2, 4, 6, 8, 10}
+	tStat, df, err := calc.TTestTwoSample(a, b)
+	require.NoError(t, err)
+	assert.InDelta(t, -3/math.Sqrt(2.5), tStat, 1e-12)
+	assert.InDelta(t, 6.25/1.0625, df, 1e-12)
+
+	// Swapping the samples flips the sign only
+	tStat, df, err = calc.TTestTwoSample(b, a)
+	require.NoError(t, err)
+	assert.InDelta(t, 3/math.Sqrt(2.5), tStat, 1e-12)
+	assert.InDelta(t, 6.25/1.0625, df, 1e-12)
+
+	// Test error cases
+	_, _, err = calc.TTestTwoSample([]float64{1}, b)
+	assert.Error(t, err)
+	assert.Equal(t, "each sample must contain at least two values", err.Error())
+
+	_, _, err = calc.TTestTwoSample(a, []float64{})
+	assert.Error(t, err)
+	assert.Equal(t, "each sample must contain at least two values", err.Error())
+
+	_, _, err = calc.TTestTwoSample([]float64{3, 3, 3}, b)
+	assert.Error(t, err)
+	assert.Equal(t, "samples must have non-zero variance", err.Error())
+}
+
+func TestCalculator_ConfidenceInterval(t *testing.T) {
+	calc := NewCalculator()
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	// 95% interval is symmetric about the mean with z ≈ 1.96
+	lower, upper, err := calc.ConfidenceInterval(values, 0.95)
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0, (lower+upper)/2, 1e-12)
+	standardError := math.Sqrt(32.0/7.0) / math.Sqrt(8)
+	assert.InDelta(t, 1.959963984540054*standardError, upper-5, 1e-9)
+
+	// Higher confidence gives a wider interval
+	wideLower, wideUpper, err := calc.ConfidenceInterval(values, 0.99)
+	require.NoError(t, err)
+	assert.Less(t, wideLower, lower)
+	assert.Greater(t, wideUpper, upper)
+
+	// Test error cases
+	_, _, err = calc.ConfidenceInterval([]float64{1}, 0.95)
+	assert.Error(t, err)
+	assert.Equal(t, "confidence interval requires at least two values", err.Error())
+
+	for _, confidence := range []float64{0, 1, -0.5, 1.5} {
+		_, _, err = calc.ConfidenceInterval(values, confidence)
+		assert.Error(t, err)
+		assert.Equal(t, "confidence must be between 0 and 1 exclusive", err.Error())
+	}
+}
+
+func TestLCG(t *testing.T) {
+	// The same seed reproduces the same sequence
+	first, second := NewLCG(42), NewLCG(42)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, first.Next(), second.Next())
+	}
+
+	// Different seeds diverge
+	assert.NotEqual(t, NewLCG(1).Next(), NewLCG(2).Next())
+
+	// Known first value for seed 0 is the increment
+	assert.Equal(t, uint64(1442695040888963407), NewLCG(0).Next())
+
+	// NextFloat stays in [0, 1) and is roughly uniform
+	generator := NewLCG(7)
+	const samples = 100000
+	var buckets [10]int
+	total := 0.0
+	for i := 0; i < samples; i++ {
+		value := generator.NextFloat()
+		require.GreaterOrEqual(t, value, 0.0)
+		require.Less(t, value, 1.0)
+		buckets[int(value*10)]++
+		total += value
+	}
+	assert.InDelta(t, 0.5, total/samples, 0.01)
+	for _, count := range buckets {
+		assert.InDelta(t, samples/10, count, samples/100)
+	}
+}
+
+func TestCalculator_ShuffleInts(t *testing.T) {
+	calc := NewCalculator()
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	// The same seed yields the same permutation
+	first := calc.ShuffleInts(items, 123)
+	second := calc.ShuffleInts(items, 123)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, items, first)
+
+	// Different seeds give different permutations
+	assert.NotEqual(t, first, calc.ShuffleInts(items, 456))
+
+	// The result is a permutation of the input
+	assert.ElementsMatch(t, items, first)
+
+	// The input is unchanged
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, items)
+
+	// Edge cases
+	assert.Equal(t, []int{}, calc.ShuffleInts([]int{}, 1))
+	assert.Equal(t, []int{7}, calc.ShuffleInts([]int{7}, 1))
+}
+
+func TestCalculator_SampleNormal(t *testing.T) {
+	calc := NewCalculator()
+
+	// Large samples approach the requested mean and standard deviation
+	samples, err := calc.SampleNormal(10, 2, 2024, 100001)
+	require.NoError(t, err)
+	require.Len(t, samples, 100001)
+	assert.InDelta(t, 10.0, mean(samples), 0.05)
+	assert.InDelta(t, 2.0, math.Sqrt(variance(samples, true)), 0.05)
+
+	// The same seed reproduces the same samples
+	again, err := calc.SampleNormal(10, 2, 2024, 100001)
+	require.NoError(t, err)
+	assert.Equal(t, samples, again)
+
+	// Zero samples
+	samples, err = calc.SampleNormal(0, 1, 1, 0)
+	require.NoError(t, err)
+	assert.Empty(t, samples)
+
+	// Test error cases
+	_, err = calc.SampleNormal(0, 0, 1, 10)
+	assert.Error(t, err)
+	assert.Equal(t, "standard deviation must be positive", err.Error())
+
+	_, err = calc.SampleNormal(0, 1, 1, -1)
+	assert.Error(t, err)
+	assert.Equal(t, "sample count cannot be negative", err.Error())
+}
+
+func TestCalculator_EmpiricalCDF(t *testing.T) {
+	calc := NewCalculator()
+	values := []float64{3, 1, 4, 1, 5, 9, 2, 6}
+
+	tests := []struct {
+		name     string
+		x        float64
+		expected float64
+	}{
+		{"below all values", 0, 0},
+		{"above all values", 10, 1},
+		{"at the maximum", 9, 1},
+		{"midpoint", 3.5, 0.5},
+		{"counts ties", 1, 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.EmpiricalCDF(values, tt.x)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	// The CDF is monotone non-decreasing in x
+	previous := 0.0
+	for x := -1.0; x <= 10; x += 0.25 {
+		result, err := calc.EmpiricalCDF(values, x)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, result, previous)
+		previous = result
+	}
+
+	// Test error case
+	_, err := calc.EmpiricalCDF([]float64{}, 1)
+	assert.Error(t, err)
+	assert.Equal(t, "values cannot be empty", err.Error())
+}
+
+func TestCalculator_BinaryDivide(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name              string
+		dividend, divisor int
+		expectedQuotient  string
+		expectedRemainder int
+	}{
+		{"13 / 4", 13, 4, "11", 1},
+		{"exact division", 12, 4, "11", 0},
+		{"power of two", 64, 8, "1000", 0},
+		{"divide by one", 5, 1, "101", 0},
+		{"dividend smaller than divisor", 3, 7, "0", 3},
+		{"zero dividend", 0, 3, "0", 0},
+		{"large values", 1000, 7, "10001110", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotient, remainder, err := calc.BinaryDivide(tt.dividend, tt.divisor)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedQuotient, quotient)
+			assert.Equal(t, tt.expectedRemainder, remainder)
+		})
+	}
+
+	// Test error cases
+	_, _, err := calc.BinaryDivide(5, 0)
+	assert.Error(t, err)
+	assert.Equal(t, "division by zero", err.Error())
+
+	_, _, err = calc.BinaryDivide(-5, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "binary division requires non-negative inputs", err.Error())
+
+	_, _, err = calc.BinaryDivide(5, -2)
+	assert.Error(t, err)
+	assert.Equal(t, "binary division requires non-negative inputs", err.Error())
+}
+
+func TestCalculator_DecomposeFloat(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name             string
+		x                float64
+		expectedSign     int
+		expectedExponent int
+		expectedMantissa uint64
+	}{
+		{"one", 1.0, 0, 0, 0},
+		{"one half", 0.5, 0, -1, 0},
+		{"one and a half", 1.5, 0, 0, 1 << 51},
+		{"negative value", -6.0, 1, 2, 1 << 51},
+		{"zero", 0, 0, -1023, 0},
+		{"negative zero", math.Copysign(0, -1), 1, -1023, 0},
+		{"smallest subnormal", math.SmallestNonzeroFloat64, 0, -1023, 1},
+		{"infinity", math.Inf(1), 0, 1024, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sign, exponent, mantissa := calc.DecomposeFloat(tt.x)
+			assert.Equal(t, tt.expectedSign, sign)
+			assert.Equal(t, tt.expectedExponent, exponent)
+			assert.Equal(t, tt.expectedMantissa, mantissa)
+		})
+	}
+}
+
+func TestCalculator_NextAfter(t *testing.T) {
+	calc := NewCalculator()
+
+	// Moving up from 1 gives the smallest float greater than 1
+	up := calc.NextAfter(1, 2)
+	assert.Greater(t, up, 1.0)
+	assert.Equal(t, 1+math.Pow(2, -52), up)
+
+	// Moving down from 1 gives the largest float less than 1
+	down := calc.NextAfter(1, 0)
+	assert.Less(t, down, 1.0)
+	assert.Equal(t, 1-math.Pow(2, -53), down)
+
+	// Moving toward itself returns the same value
+	assert.Equal(t, 3.5, calc.NextAfter(3.5, 3.5))
+
+	// Moving up from zero gives the smallest subnormal
+	assert.Equal(t, math.SmallestNonzeroFloat64, calc.NextAfter(0, 1))
+}
+
+func TestCalculator_FlushDenormals(t *testing.T) {
+	calc := NewCalculator()
+	smallestNormal := math.SmallestNonzeroFloat64 * (1 << 52)
+
+	tests := []struct {
+		name     string
+		x        float64
+		expected float64
+	}{
+		{"normal value", 3.14, 3.14},
+		{"smallest normal", smallestNormal, smallestNormal},
+		{"negative normal", -1e-300, -1e-300},
+		{"smallest subnormal", math.SmallestNonzeroFloat64, 0},
+		{"largest subnormal", math.Nextafter(smallestNormal, 0), 0},
+		{"negative subnormal", -1e-310, 0},
+		{"zero", 0, 0},
+		{"infinity", math.Inf(1), math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calc.FlushDenormals(tt.x))
+		})
+	}
+}
+
+func TestCalculator_EvalRational(t *testing.T) {
+	calc := NewCalculator()
+
+	// (x² + 1) / (x - 1)
+	numerator := []float64{1, 0, 1}
+	denominator := []float64{1, -1}
+
+	tests := []struct {
+		name     string
+		x        float64
+		expected float64
+	}{
+		{"at zero", 0, -1},
+		{"at two", 2, 5},
+		{"at negative one", -1, -1},
+		{"at three", 3, 5},
+		{"fractional point", 0.5, -2.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.EvalRational(numerator, denominator, tt.x)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-12)
+		})
+	}
+
+	// Constant denominator reduces to polynomial evaluation
+	result, err := calc.EvalRational([]float64{2, 3, 4}, []float64{2}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 9.0, result)
+
+	// Test error cases
+	_, err = calc.EvalRational(numerator, denominator, 1)
+	assert.Error(t, err)
+	assert.Equal(t, "division by zero", err.Error())
+
+	_, err = calc.EvalRational(numerator, []float64{}, 1)
+	assert.Error(t, err)
+	assert.Equal(t, "denominator polynomial cannot be empty", err.Error())
+}
+
+func TestCalculator_PartitionsIntoKParts(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n, k     int
+		expected int
+	}{
+		{"five into two parts", 5, 2, 2},
+		{"single part", 9, 1, 1},
+		{"all ones", 6, 6, 1},
+		{"ten into three parts", 10, 3, 8},
+		{"more parts than n", 3, 5, 0},
+		{"zero into zero parts", 0, 0, 1},
+		{"positive into zero parts", 4, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.PartitionsIntoKParts(tt.n, tt.k)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	// Summing over k gives the total partition count p(10) = 42
+	total := 0
+	for k := 1; k <= 10; k++ {
+		count, err := calc.PartitionsIntoKParts(10, k)
+		require.NoError(t, err)
+		total += count
+	}
+	assert.Equal(t, 42, total)
+
+	// Test error cases
+	_, err := calc.PartitionsIntoKParts(-1, 2)
+	assert.Error(t, err)
+	assert.Equal(t, "partitions are not defined for negative numbers", err.Error())
+
+	_, err = calc.PartitionsIntoKParts(5, -2)
+	assert.Error(t, err)
+	assert.Equal(t, "partitions are not defined for negative numbers", err.Error())
+}
+
+func TestCalculator_BinaryGCD(t *testing.T) {
+	calc := NewCalculator()
+
+	// Agrees with the Euclidean GCD across a range, including zeros and negatives
+	for a := -60; a <= 60; a++ {
+		for b := -60; b <= 60; b++ {
+			require.Equal(t, calc.GCD(a, b), calc.BinaryGCD(a, b), "GCD(%d, %d)", a, b)
+		}
+	}
+
+	// Test known values
+	assert.Equal(t, 6, calc.BinaryGCD(48, 18))
+	assert.Equal(t, 1, calc.BinaryGCD(7, 13))
+	assert.Equal(t, 0, calc.BinaryGCD(0, 0))
+	assert.Equal(t, 1<<20, calc.BinaryGCD(1<<20, 3<<20))
+	assert.Equal(t, 1, calc.BinaryGCD(1000000007, 998244353))
+}
+
+func BenchmarkCalculator_GCD(b *testing.B) {
+	calc := NewCalculator()
+
+	b.Run("euclidean", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = calc.GCD(1134903170, 1836311903)
+		}
+	})
+	b.Run("binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = calc.BinaryGCD(1134903170, 1836311903)
+		}
+	})
+}
+
+func TestCalculator_MovingSum(t *testing.T) {
+	calc := NewCalculator()
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	for window := 1; window <= len(values); window++ {
+		result, err := calc.MovingSum(values, window)
+		require.NoError(t, err)
+		require.Len(t, result, len(values)-window+1)
+		for i := range result {
+			direct := 0.0
+			for _, v := range values[i : i+window] {
+				direct += v
+			}
+			assert.Equal(t, direct, result[i], "window %d at %d", window, i)
+		}
+	}
+
+	// A window equal to the length gives the total
+	result, err := calc.MovingSum(values, len(values))
+	require.NoError(t, err)
+	assert.Equal(t, []float64{55}, result)
+
+	// Test error cases
+	_, err = calc.MovingSum(values, 0)
+	assert.Error(t, err)
+	assert.Equal(t, "window must be positive", err.Error())
+
+	_, err = calc.MovingSum(values, len(values)+1)
+	assert.Error(t, err)
+	assert.Equal(t, "window cannot exceed the number of values", err.Error())
+}
+
+func BenchmarkCalculator_MovingSum(b *testing.B) {
+	calc := NewCalculator()
+	values := make([]float64, 100000)
+	for i := range values {
+		values[i] = float64(i % 97)
+	}
+
+	// The cost should stay flat as the window grows.
+	for _, window := range []int{10, 1000, 10000} {
+		b.Run(fmt.Sprintf("window=%d", window), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = calc.MovingSum(values, window)
+			}
+		})
+	}
+}
+
+func TestCalculator_Autocorrelation(t *testing.T) {
+	calc := NewCalculator()
+
+	// A signal with period 8
+	signal := make([]float64, 200)
+	for i := range signal {
+		signal[i] = math.Sin(2 * math.Pi * float64(i) / 8)
+	}
+
+	// Lag 0 is always 1
+	result, err := calc.Autocorrelation(signal, 0)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, result, 1e-12)
+
+	// High correlation at the period, strong anti-correlation at half the period
+	atPeriod, err := calc.Autocorrelation(signal, 8)
+	require.NoError(t, err)
+	assert.Greater(t, atPeriod, 0.9)
+	atHalfPeriod, err := calc.Autocorrelation(signal, 4)
+	require.NoError(t, err)
+	assert.Less(t, atHalfPeriod, -0.9)
+
+	// Hand-computed: [1 2 3 4] has mean 2.5, lag-1 sum 1.25/5
+	result, err = calc.Autocorrelation([]float64{1, 2, 3, 4}, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, result, 1e-12)
+
+	// Test error cases
+	tests := []struct {
+		name          string
+		values        []float64
+		lag           int
+		expectedError string
+	}{
+		{"negative lag", signal, -1, "lag must be between 0 and the number of values minus 1"},
+		{"lag equal to length", signal, len(signal), "lag must be between 0 and the number of values minus 1"},
+		{"too few values", []float64{1}, 0, "autocorrelation requires at least two values"},
+		{"constant values", []float64{2, 2, 2}, 1, "autocorrelation is undefined for constant values"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.Autocorrelation(tt.values, tt.lag)
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedError, err.Error())
+		})
+	}
+}
+
+func TestCalculator_Convolve(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		a, b     []float64
+		expected []float64
+	}{
+		{"known result", []float64{1, 2, 3}, []float64{0, 1, 0.5}, []float64{0, 1, 2.5, 4, 1.5}},
+		{"unit impulse", []float64{4, -2, 7}, []float64{1}, []float64{4, -2, 7}},
+		{"shifted impulse", []float64{4, -2, 7}, []float64{0, 1}, []float64{0, 4, -2, 7}},
+		{"polynomial product", []float64{1, 1}, []float64{1, 1}, []float64{1, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Convolve(tt.a, tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	// Test error cases
+	_, err := calc.Convolve([]float64{}, []float64{1})
+	assert.Error(t, err)
+	assert.Equal(t, "convolution requires non-empty inputs", err.Error())
+
+	_, err = calc.Convolve([]float64{1}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, "convolution requires non-empty inputs", err.Error())
+}
+
+func TestCalculator_ExactSum(t *testing.T) {
+	calc := NewCalculator()
+
+	// float64 summation loses the small terms entirely
+	values := []float64{1, 1e20, 1, -1e20}
+	naive := 0.0
+	for _, v := range values {
+		naive += v
+	}
+	assert.Equal(t, 0.0, naive)
+
+	result, err := calc.ExactSum(values, 256)
+	require.NoError(t, err)
+	exact, accuracy := result.Float64()
+	assert.Equal(t, 2.0, exact)
+	assert.Equal(t, big.Exact, accuracy)
+
+	// Widely separated magnitudes need more precision
+	result, err = calc.ExactSum([]float64{1e100, 1, -1e100}, 512)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Cmp(big.NewFloat(1)))
+
+	// The precision is respected
+	result, err = calc.ExactSum([]float64{0.1}, 128)
+	require.NoError(t, err)
+	assert.Equal(t, uint(128), result.Prec())
+
+	// Empty input sums to zero
+	result, err = calc.ExactSum(nil, 64)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Sign())
+
+	// Test error cases
+	errorTests := []struct {
+		name          string
+		values        []float64
+		prec          uint
+		expectedError string
+	}{
+		{"NaN", []float64{1, math.NaN()}, 256, "values must be finite"},
+		{"positive infinity", []float64{math.Inf(1)}, 256, "values must be finite"},
+		{"opposite infinities", []float64{math.Inf(1), 1, math.Inf(-1)}, 256, "values must be finite"},
+		{"zero precision", []float64{1e20, 1, -1e20}, 0, "precision must be positive"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.ExactSum(tt.values, tt.prec)
+			assert.Nil(t, result)
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedError, err.Error())
+		})
+	}
+}
+
+func TestCalculator_EvalRPN(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		tokens        []string
+		expected      float64
+		expectedError string
+	}{
+		{"sum then product", []string{"3", "4", "+", "5", "*"}, 35, ""},
+		{"operand order", []string{"10", "4", "-"}, 6, ""},
+		{"division", []string{"7", "2", "/"}, 3.5, ""},
+		{"power", []string{"2", "3", "^", "2", "^"}, 64, ""},
+		{"decimals and negatives", []string{"-1.5", "2", "*"}, -3, ""},
+		{"single number", []string{"42"}, 42, ""},
+		{"division by zero", []string{"1", "0", "/"}, 0, "division by zero"},
+		{"insufficient operands", []string{"3", "+"}, 0, "insufficient operands"},
+		{"too many operands", []string{"3", "4"}, 0, "too many operands"},
+		{"invalid token", []string{"3", "x", "+"}, 0, "invalid token: x"},
+		{"empty expression", []string{}, 0, "empty expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.EvalRPN(tt.tokens)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_FactorialOverflow(t *testing.T) {
+	calc := NewCalculator()
+
+	// 20! is the largest factorial that fits in a 64-bit int
+	result, err := calc.Factorial(20)
+	require.NoError(t, err)
+	assert.Equal(t, 2432902008176640000, result)
+
+	_, err = calc.Factorial(21)
+	assert.Error(t, err)
+	assert.Equal(t, "factorial result overflows int", err.Error())
+
+	_, err = calc.Factorial(100)
+	assert.Error(t, err)
+	assert.Equal(t, "factorial result overflows int", err.Error())
+}
+
+func TestCalculator_FactorialBig(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n        int
+		expected string
+	}{
+		{"zero", 0, "1"},
+		{"one", 1, "1"},
+		{"twenty", 20, "2432902008176640000"},
+		{"twenty-one", 21, "51090942171709440000"},
+		{"one hundred", 100, "93326215443944152681699238856266700490715968264381621468592963895217599993229915608941463976156518286253697920827223758251185210916864000000000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.FactorialBig(tt.n)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.String())
+		})
+	}
+
+	// Test error case
+	_, err := calc.FactorialBig(-1)
+	assert.Error(t, err)
+	assert.Equal(t, "factorial is not defined for negative numbers", err.Error())
+}
+
+func TestCalculator_InfixToPostfix(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected []string
+	}{
+		{"precedence", "3 + 4 * 2", []string{"3", "4", "2", "*", "+"}},
+		{"left associativity", "8 - 3 - 1", []string{"8", "3", "-", "1", "-"}},
+		{"right associative power", "2 ^ 3 ^ 2", []string{"2", "3", "2", "^", "^"}},
+		{"parentheses", "(3 + 4) * 2", []string{"3", "4", "+", "2", "*"}},
+		{"nested parentheses", "((1 + 2) * (3 - 4)) / 5", []string{"1", "2", "+", "3", "4", "-", "*", "5", "/"}},
+		{"no whitespace", "1.5*2+3", []string{"1.5", "2", "*", "3", "+"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.InfixToPostfix(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	// The postfix form evaluates to the expected value
+	tokens, err := calc.InfixToPostfix("3 + 4 * 2 / (1 - 5) ^ 2 ^ 3")
+	require.NoError(t, err)
+	result, err := calc.EvalRPN(tokens)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0001220703125, result, 1e-12)
+
+	// Test error cases
+	errorTests := []struct {
+		name          string
+		expr          string
+		expectedError string
+	}{
+		{"unclosed parenthesis", "(1 + 2", "unmatched '(' at position 0"},
+		{"unopened parenthesis", "1 + 2)", "unmatched ')' at position 5"},
+		{"reversed parentheses", ")(", "unmatched ')' at position 0"},
+		{"invalid character", "1 + #", "invalid character '#' at position 4"},
+		{"adjacent numbers", "3 4", "unexpected \"4\" at position 2"},
+		{"trailing operator", "3 +", "expected operand at position 3"},
+		{"leading operator", "* 2", "expected operand at position 0"},
+		{"empty", "", "empty expression"},
+		{"unknown identifiers", "a + b", "undefined variable: a"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.InfixToPostfix(tt.expr)
+			assert.Nil(t, result)
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedError, err.Error())
+		})
+	}
+
+	tokens, err = calc.InfixToPostfix("2 * pi")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "3.141592653589793", "*"}, tokens)
+}
+
+func TestCalculator_Tokenize(t *testing.T) {
+	calc := NewCalculator()
+
+	// A complex expression produces the right token stream
+	tokens, err := calc.Tokenize("-(3.5 + 4) * 2^-1 - 7")
+	require.NoError(t, err)
+	assert.Equal(t, []Token{
+		{Kind: TokenOperator, Value: "neg", Pos: 0},
+		{Kind: TokenLeftParen, Value: "(", Pos: 1},
+		{Kind: TokenNumber, Value: "3.5", Pos: 2},
+		{Kind: TokenOperator, Value: "+", Pos: 6},
+		{Kind: TokenNumber, Value: "4", Pos: 8},
+		{Kind: TokenRightParen, Value: ")", Pos: 9},
+		{Kind: TokenOperator, Value: "*", Pos: 11},
+		{Kind: TokenNumber, Value: "2", Pos: 13},
+		{Kind: TokenOperator, Value: "^", Pos: 14},
+		{Kind: TokenOperator, Value: "neg", Pos: 15},
+		{Kind: TokenNumber, Value: "1", Pos: 16},
+		{Kind: TokenOperator, Value: "-", Pos: 18},
+		{Kind: TokenNumber, Value: "7", Pos: 20},
+	}, tokens)
+
+	// Scientific notation numbers are single tokens
+	tokens, err = calc.Tokenize("1.5e3+2E-2*.5e+1")
+	require.NoError(t, err)
+	var values []string
+	for _, token := range tokens {
+		values = append(values, token.Value)
+	}
+	assert.Equal(t, []string{"1.5e3", "+", "2E-2", "*", ".5e+1"}, values)
+
+	// Empty expression has no tokens
+	tokens, err = calc.Tokenize("   ")
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+
+	// Test error cases
+	_, err = calc.Tokenize("2 $ 3")
+	assert.Error(t, err)
+	assert.Equal(t, "invalid character '$' at position 2", err.Error())
+
+	_, err = calc.Tokenize("1.2.3 + 1")
+	assert.Error(t, err)
+	assert.Equal(t, "invalid number \"1.2.3\" at position 0", err.Error())
+}
+
+func TestCalculator_InfixToPostfixUnaryMinus(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected float64
+	}{
+		{"leading minus", "-3 + 5", 2},
+		{"negated group", "-(2 + 3) * 2", -10},
+		{"power binds tighter", "-2 ^ 2", -4},
+		{"negative exponent", "2 ^ -1", 0.5},
+		{"after operator", "4 * -2", -8},
+		{"double negation", "--3", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := calc.InfixToPostfix(tt.expr)
+			require.NoError(t, err)
+			result, err := calc.EvalRPN(tokens)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCalculator_NthRoot(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		number        float64
+		n             float64
+		expected      float64
+		expectedError string
+	}{
+		{"cube root", 27, 3, 3, ""},
+		{"cube root of negative", -27, 3, -3, ""},
+		{"fifth root of negative", -32, 5, -2, ""},
+		{"square root", 16, 2, 4, ""},
+		{"fourth root", 81, 4, 3, ""},
+		{"first root", -7, 1, -7, ""},
+		{"fractional degree", 8, 1.5, 4, ""},
+		{"negative degree", 8, -3, 0.5, ""},
+		{"zero", 0, 3, 0, ""},
+		{"zero degree", 8, 0, 0, "root degree cannot be zero"},
+		{"even root of negative", -16, 4, 0, "cannot calculate even root of negative number"},
+		{"fractional root of negative", -8, 1.5, 0, "cannot calculate non-integer root of negative number"},
+		{"zero with negative degree", 0, -2, 0, "division by zero"},
+		{"zero with negative fractional degree", 0, -1.5, 0, "division by zero"},
+		{"huge odd degree of negative", -1, 1<<32 + 1, -1, ""},
+		{"huge even degree of negative", -8, 1 << 40, 0, "cannot calculate even root of negative number"},
+		{"huge degree", 1, 1 << 40, 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.NthRoot(tt.number, tt.n)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_EvalWithVars(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		expr          string
+		vars          map[string]float64
+		expected      float64
+		expectedError string
+	}{
+		{"two variables", "rate * hours + bonus_1", map[string]float64{"rate": 12.5, "hours": 8, "bonus_1": 20}, 120, ""},
+		{"negated variable", "-x ^ 2", map[string]float64{"x": 3}, -9, ""},
+		{"negative value", "x - y", map[string]float64{"x": -1.5, "y": -4}, 2.5, ""},
+		{"shadows constant", "2 * pi", map[string]float64{"pi": 3}, 6, ""},
+		{"no variables", "(1 + 2) * 3", nil, 9, ""},
+		{"undefined variable", "x + y", map[string]float64{"x": 1}, 0, "undefined variable: y"},
+		{"division by zero", "1 / x", map[string]float64{"x": 0}, 0, "division by zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.EvalWithVars(tt.expr, tt.vars)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_Evaluate(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		expr          string
+		expected      float64
+		expectedError string
+	}{
+		{"integration expression", "(5 + 3) * 2 - 4 / 2", 14, ""},
+		{"multiplication before addition", "2 + 3 * 4", 14, ""},
+		{"left associative subtraction", "10 - 4 - 3", 3, ""},
+		{"right associative power", "2 ^ 3 ^ 2", 512, ""},
+		{"modulo", "17 % 5 + 1", 3, ""},
+		{"modulo shares precedence with multiplication", "2 * 7 % 4", 2, ""},
+		{"nested parentheses", "((2 + 3) * (4 - (1 + 1))) / 5", 2, ""},
+		{"unary minus", "-(2 + 3) * -2", 10, ""},
+		{"no whitespace", "(5+3)*2-4/2", 14, ""},
+		{"extra whitespace", "  ( 5 +\t3 )  *2 ", 16, ""},
+		{"division by zero", "1 / (2 - 2)", 0, "division by zero"},
+		{"modulo by zero", "5 % 0", 0, "modulo by zero"},
+		{"empty", "   ", 0, "empty expression"},
+		{"missing operand", "1 + * 2", 0, "expected operand at position 4"},
+		{"trailing operator", "1 +", 0, "expected operand at position 3"},
+		{"empty parentheses", "2 * ()", 0, "expected operand at position 5"},
+		{"adjacent numbers", "1 2", 0, "unexpected \"2\" at position 2"},
+		{"implicit multiplication", "2(3)", 0, "unexpected \"(\" at position 1"},
+		{"unmatched close", "1 + 2)", 0, "unmatched ')' at position 5"},
+		{"unmatched open", "(1 + (2 * 3)", 0, "unmatched '(' at position 0"},
+		{"invalid character", "1 & 2", 0, "invalid character '&' at position 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Evaluate(tt.expr)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_EvalWithVarsBuiltins(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		expr          string
+		vars          map[string]float64
+		expected      float64
+		expectedError string
+	}{
+		{"sqrt plus sin", "sqrt(16) + sin(0)", nil, 4, ""},
+		{"pi constant", "cos(pi)", nil, -1, ""},
+		{"e constant", "log(e ^ 2)", nil, 2, ""},
+		{"nested calls", "sqrt(sqrt(81)) * -cos(0)", nil, -3, ""},
+		{"function of variables", "sqrt(x * x + y * y)", map[string]float64{"x": 3, "y": 4}, 5, ""},
+		{"power of function", "sqrt(4) ^ 3", nil, 8, ""},
+		{"variable shadows e", "e * 2", map[string]float64{"e": 5}, 10, ""},
+		{"sqrt of negative", "1 + sqrt(-4)", nil, 0, "cannot calculate square root of negative number"},
+		{"log of zero", "log(1 - 1)", nil, 0, "logarithm is not defined for non-positive numbers"},
+		{"unknown function", "tan(1)", nil, 0, "unknown function: tan"},
+		{"function without argument", "sqrt()", nil, 0, "expected operand at position 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.EvalWithVars(tt.expr, tt.vars)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+
+	result, err := calc.Evaluate("2 * pi")
+	require.NoError(t, err)
+	assert.InDelta(t, 2*math.Pi, result, 1e-12)
+}
+
+func TestCalculator_Memory(t *testing.T) {
+	calc := NewCalculator()
+	assert.Equal(t, 0.0, calc.MemoryRecall())
+
+	calc.MemoryAdd(10)
+	calc.MemoryAdd(5.5)
+	calc.MemorySubtract(3)
+	assert.Equal(t, 12.5, calc.MemoryRecall())
+
+	calc.MemorySubtract(20)
+	assert.Equal(t, -7.5, calc.MemoryRecall())
+	assert.Equal(t, -7.5, calc.MemoryRecall(), "recall should not change the register")
+
+	calc.MemoryClear()
+	assert.Equal(t, 0.0, calc.MemoryRecall())
+
+	calc.MemoryAdd(2)
+	assert.Equal(t, 2.0, calc.MemoryRecall())
+
+	other := NewCalculator()
+	assert.Equal(t, 0.0, other.MemoryRecall(), "each calculator has its own register")
+}
+
+func TestCalculator_ILogBase(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		n             int
+		base          int
+		expected      int
+		expectedError string
+	}{
+		{"exact power of ten", 100, 10, 2, ""},
+		{"exact power of two", 8, 2, 3, ""},
+		{"between powers", 7, 2, 2, ""},
+		{"just below power", 999, 10, 2, ""},
+		{"one", 1, 7, 0, ""},
+		{"less than base", 6, 7, 0, ""},
+		{"float log would round up", 1<<53 - 1, 2, 52, ""},
+		{"max int", math.MaxInt64, 2, 62, ""},
+		{"zero", 0, 10, 0, "logarithm is not defined for non-positive numbers"},
+		{"negative", -8, 2, 0, "logarithm is not defined for non-positive numbers"},
+		{"base one", 8, 1, 0, "logarithm base must be greater than 1"},
+		{"base zero", 8, 0, 0, "logarithm base must be greater than 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.ILogBase(tt.n, tt.base)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_History(t *testing.T) {
+	calc := NewCalculator()
+
+	calc.Add(1, 2)
+	assert.Empty(t, calc.History(), "history should be off by default")
+
+	calc.EnableHistory(true)
+	calc.Add(2, 3)
+	_, _ = calc.Divide(10, 4)
+	_, _ = calc.Divide(1, 0)
+	_, _ = calc.Factorial(4)
+	_, _ = calc.Sqrt(-1)
+	calc.Multiply(3, 3)
+
+	divErr := errors.New("division by zero")
+	sqrtErr := errors.New("cannot calculate square root of negative number")
+	expected := []Operation{
+		{Name: "Add", Inputs: []float64{2, 3}, Result: 5},
+		{Name: "Divide", Inputs: []float64{10, 4}, Result: 2.5},
+		{Name: "Divide", Inputs: []float64{1, 0}, Result: 0, Err: divErr},
+		{Name: "Factorial", Inputs: []float64{4}, Result: 24},
+		{Name: "Sqrt", Inputs: []float64{-1}, Result: 0, Err: sqrtErr},
+		{Name: "Multiply", Inputs: []float64{3, 3}, Result: 9},
+	}
+	assert.Equal(t, expected, calc.History())
+
+	history := calc.History()
+	history[0].Name = "changed"
+	assert.Equal(t, "Add", calc.History()[0].Name, "History should return a copy")
+	history[0].Inputs[0] = 100
+	assert.Equal(t, []float64{2, 3}, calc.History()[0].Inputs, "History should copy the inputs")
+
+	calc.EnableHistory(false)
+	calc.Subtract(5, 1)
+	assert.Len(t, calc.History(), len(expected))
+
+	calc.ClearHistory()
+	assert.Empty(t, calc.History())
+
+	calc.EnableHistory(true)
+	_, _ = calc.Modulo(7, 0)
+	require.Len(t, calc.History(), 1)
+	assert.EqualError(t, calc.History()[0].Err, "modulo by zero")
+}
+
+func TestCalculator_HistoryInternalCalls(t *testing.T) {
+	calc := NewCalculator()
+	calc.EnableHistory(true)
+
+	// Only direct calls to recorded methods belong in the history, not the
+	// arithmetic performed internally by higher-level methods.
+	calc.Add(1, 2)
+	_, err := calc.Evaluate("-3 + 4 * sqrt(16) / 2 % 5 ^ 1")
+	require.NoError(t, err)
+	_, err = calc.EvalRational([]float64{1, 0}, []float64{1, 1}, 2)
+	require.NoError(t, err)
+	_, err = calc.EvalRational([]float64{1}, []float64{1, -2}, 2)
+	assert.EqualError(t, err, "division by zero")
+	_, err = calc.SolveQuadratic(1, -5, 6)
+	require.NoError(t, err)
+	calc.Multiply(2, 3)
+
+	expected := []Operation{
+		{Name: "Add", Inputs: []float64{1, 2}, Result: 3},
+		{Name: "Multiply", Inputs: []float64{2, 3}, Result: 6},
+	}
+	assert.Equal(t, expected, calc.History())
+}
+
+func BenchmarkCalculator_AddHistoryDisabled(b *testing.B) {
+	calc := NewCalculator()
+	for i := 0; i < b.N; i++ {
+		calc.Add(float64(i), 1)
+	}
+}
+
+func TestCalculator_LargestTriangularBelow(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		n             int
+		expectedIndex int
+		expectedValue int
+		expectedError string
+	}{
+		{"triangular number", 10, 4, 10, ""},
+		{"just above triangular", 11, 4, 10, ""},
+		{"just below triangular", 14, 4, 10, ""},
+		{"zero", 0, 0, 0, ""},
+		{"one", 1, 1, 1, ""},
+		{"two", 2, 1, 1, ""},
+		{"large triangular", 500000500000, 1000000, 500000500000, ""},
+		{"below large triangular", 500000499999, 999999, 499999500000, ""},
+		{"max int", math.MaxInt64, 4294967295, 9223372034707292160, ""},
+		{"negative", -1, 0, 0, "n cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, value, err := calc.LargestTriangularBelow(tt.n)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedIndex, index)
+				assert.Equal(t, tt.expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestCalculator_ComplexArithmetic(t *testing.T) {
+	calc := NewCalculator()
+
+	assert.Equal(t, complex(4, 6), calc.AddComplex(complex(1, 2), complex(3, 4)))
+	assert.Equal(t, complex(-2, -2), calc.SubtractComplex(complex(1, 2), complex(3, 4)))
+	assert.Equal(t, complex(-5, 10), calc.MultiplyComplex(complex(1, 2), complex(3, 4)))
+	assert.Equal(t, complex(-1, 0), calc.MultiplyComplex(1i, 1i))
+
+	result, err := calc.DivideComplex(complex(-5, 10), complex(3, 4))
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, real(result), 1e-12)
+	assert.InDelta(t, 2.0, imag(result), 1e-12)
+
+	_, err = calc.DivideComplex(complex(1, 1), 0)
+	assert.Error(t, err)
+	assert.Equal(t, "division by zero", err.Error())
+}
+
+func TestCalculator_SqrtComplex(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		z        complex128
+		expected complex128
+	}{
+		{"negative real", -4, 2i},
+		{"minus one", -1, 1i},
+		{"positive real", 9, 3},
+		{"zero", 0, 0},
+		{"imaginary", 2i, complex(1, 1)},
+		{"general", complex(3, 4), complex(2, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calc.SqrtComplex(tt.z)
+			assert.InDelta(t, real(tt.expected), real(result), 1e-12)
+			assert.InDelta(t, imag(tt.expected), imag(result), 1e-12)
+		})
+	}
+}
+
+func TestCalculator_FareySequence(t *testing.T) {
+	calc := NewCalculator()
+
+	sequence, err := calc.FareySequence(3)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int{{0, 1}, {1, 3}, {1, 2}, {2, 3}, {1, 1}}, sequence)
+
+	sequence, err = calc.FareySequence(1)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int{{0, 1}, {1, 1}}, sequence)
+
+	// |F(n)| = 1 + phi(1) + ... + phi(n).
+	expectedLengths := []int{2, 3, 5, 7, 11, 13, 19, 23, 29, 33}
+	for i, expected := range expectedLengths {
+		n := i + 1
+		sequence, err := calc.FareySequence(n)
+		require.NoError(t, err)
+		assert.Len(t, sequence, expected, "order %d", n)
+
+		for j := 1; j < len(sequence); j++ {
+			prev, cur := sequence[j-1], sequence[j]
+			assert.Equal(t, 1, cur[0]*prev[1]-prev[0]*cur[1], "neighbors in order %d", n)
+			assert.Equal(t, 1, calc.GCD(cur[0], cur[1]))
+		}
+	}
+
+	for _, n := range []int{0, -3} {
+		_, err := calc.FareySequence(n)
+		assert.Error(t, err)
+		assert.Equal(t, "farey sequence order must be at least 1", err.Error())
+	}
+}
+
+func TestCalculator_MeanMedian(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name           string
+		values         []float64
+		expectedMean   float64
+		expectedMedian float64
+	}{
+		{"single value", []float64{7}, 7, 7},
+		{"odd length unsorted", []float64{3, 1, 2}, 2, 2},
+		{"even length", []float64{4, 1, 3, 2}, 2.5, 2.5},
+		{"even length skewed", []float64{1, 2, 10, 100}, 28.25, 6},
+		{"negative values", []float64{-5, -1, -3}, -3, -3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]float64(nil), tt.values...)
+
+			m, err := calc.Mean(tt.values)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expectedMean, m, 1e-12)
+
+			med, err := calc.Median(tt.values)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expectedMedian, med, 1e-12)
+			assert.Equal(t, original, tt.values, "input should not be modified")
+		})
+	}
+
+	_, err := calc.Mean(nil)
+	assert.EqualError(t, err, "values cannot be empty")
+	_, err = calc.Median([]float64{})
+	assert.EqualError(t, err, "values cannot be empty")
+}
+
+func TestCalculator_VarianceStdDev(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name             string
+		values           []float64
+		sample           bool
+		expectedVariance float64
+		expectedError    string
+	}{
+		{"population", []float64{2, 4, 4, 4, 5, 5, 7, 9}, false, 4, ""},
+		{"sample", []float64{2, 4, 4, 4, 5, 5, 7, 9}, true, 32.0 / 7, ""},
+		{"constant values", []float64{3, 3, 3}, true, 0, ""},
+		{"single value population", []float64{5}, false, 0, ""},
+		{"single value sample", []float64{5}, true, 0, "sample variance requires at least two values"},
+		{"empty population", nil, false, 0, "values cannot be empty"},
+		{"empty sample", []float64{}, true, 0, "values cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := calc.Variance(tt.values, tt.sample)
+			sd, sdErr := calc.StdDev(tt.values, tt.sample)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.EqualError(t, sdErr, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, sdErr)
+				assert.InDelta(t, tt.expectedVariance, v, 1e-12)
+				assert.InDelta(t, math.Sqrt(tt.expectedVariance), sd, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_ModSqrt(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name string
+		n    int
+		p    int
+	}{
+		{"small prime", 10, 13},
+		{"p = 3 mod 4", 2, 7},
+		{"p = 1 mod 8", 5, 41},
+		{"high power of two in p-1", 2, 257},
+		{"n larger than p", 23, 13},
+		{"negative n", -3, 13},
+		{"zero", 0, 11},
+		{"large prime", 2, 1000000007},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := calc.ModSqrt(tt.n, tt.p)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, r, 0)
+			assert.LessOrEqual(t, r, tt.p/2)
+			expected := (tt.n%tt.p + tt.p) % tt.p
+			assert.Equal(t, expected, r*r%tt.p)
+		})
+	}
+
+	r, err := calc.ModSqrt(10, 13)
+	require.NoError(t, err)
+	assert.Equal(t, 6, r)
+
+	errorTests := []struct {
+		name          string
+		n             int
+		p             int
+		expectedError string
+	}{
+		{"non-residue", 5, 13, "no square root exists"},
+		{"non-residue mod large prime", 5, 1000000007, "no square root exists"},
+		{"composite modulus", 4, 15, "modulus must be an odd prime"},
+		{"even prime", 1, 2, "modulus must be an odd prime"},
+		{"one", 1, 1, "modulus must be an odd prime"},
+		{"negative modulus", 1, -7, "modulus must be an odd prime"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.ModSqrt(tt.n, tt.p)
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedError, err.Error())
+		})
+	}
+}
+
+func TestCalculator_LCMChecked(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		a, b          int
+		expected      int
+		expectedError string
+	}{
+		{"positive numbers", 12, 18, 36, ""},
+		{"negative numbers", -12, -18, 36, ""},
+		{"with zero", 0, 5, 0, ""},
+		{"large coprime numbers", 1000000, 999999, 999999000000, ""},
+		{"product overflows but lcm fits", 1 << 40, 1 << 41, 1 << 41, ""},
+		{"largest fitting result", math.MaxInt64, 1, math.MaxInt64, ""},
+		{"min int with power of two", math.MinInt64, 2, 0, "lcm result overflows int"},
+		{"coprime overflow", 4294967311, 4294967357, 0, "lcm result overflows int"},
+		{"overflow with common factor", math.MaxInt64, 2, 0, "lcm result overflows int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.LCMChecked(tt.a, tt.b)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_IsProbablePrime(t *testing.T) {
+	calc := NewCalculator()
+
+	for n := -10; n <= 10000; n++ {
+		expected := calc.IsPrime(n)
+		assert.Equal(t, expected, calc.IsProbablePrime(n, 0), "deterministic, n = %d", n)
+		assert.Equal(t, expected, calc.IsProbablePrime(n, 8), "random witnesses, n = %d", n)
+	}
+
+	tests := []struct {
+		name     string
+		n        int
+		expected bool
+	}{
+		{"large prime", 1000000007, true},
+		{"12-digit prime", 999999999989, true},
+		{"mersenne prime", 2305843009213693951, true},
+		{"largest int64 prime", 9223372036854775783, true},
+		{"carmichael number", 561, false},
+		{"strong pseudoprime to bases 2, 3, 5, 7", 3215031751, false},
+		{"semiprime", 1000000007 * 998244353, false},
+		{"product of two 31-bit primes", 2147483647 * 2147483629, false},
+		{"max int", math.MaxInt64, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calc.IsProbablePrime(tt.n, 0))
+			assert.Equal(t, tt.expected, calc.IsProbablePrime(tt.n, 20))
+		})
+	}
+}
+
+func BenchmarkCalculator_IsProbablePrime(b *testing.B) {
+	calc := NewCalculator()
+	for i := 0; i < b.N; i++ {
+		calc.IsProbablePrime(999999999989, 0)
+	}
+}
+
+func BenchmarkCalculator_IsPrimeTrialDivision(b *testing.B) {
+	calc := NewCalculator()
+	for i := 0; i < b.N; i++ {
+		calc.IsPrime(999999999989)
+	}
+}
+
+func TestCalculator_InverseTrigonometric(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		fn            func(float64) (float64, error)
+		x             float64
+		expected      float64
+		expectedError string
+	}{
+		{"asin of 1", calc.Asin, 1, math.Pi / 2, ""},
+		{"asin of -1", calc.Asin, -1, -math.Pi / 2, ""},
+		{"asin of 0", calc.Asin, 0, 0, ""},
+		{"asin of 0.5", calc.Asin, 0.5, math.Pi / 6, ""},
+		{"asin above range", calc.Asin, 1.0001, 0, "arcsine is only defined on [-1, 1]"},
+		{"asin below range", calc.Asin, -2, 0, "arcsine is only defined on [-1, 1]"},
+		{"acos of 1", calc.Acos, 1, 0, ""},
+		{"acos of -1", calc.Acos, -1, math.Pi, ""},
+		{"acos of 0", calc.Acos, 0, math.Pi / 2, ""},
+		{"acos of 0.5", calc.Acos, 0.5, math.Pi / 3, ""},
+		{"acos above range", calc.Acos, 2, 0, "arccosine is only defined on [-1, 1]"},
+		{"acos of NaN", calc.Acos, math.NaN(), math.NaN(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fn(tt.x)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else if math.IsNaN(tt.expected) {
+				assert.NoError(t, err)
+				assert.True(t, math.IsNaN(result))
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+
+	assert.InDelta(t, math.Pi/4, calc.Atan(1), 1e-12)
+	assert.InDelta(t, -math.Pi/4, calc.Atan(-1), 1e-12)
+	assert.InDelta(t, math.Pi/2, calc.Atan(math.Inf(1)), 1e-12)
+	assert.Equal(t, 0.0, calc.Atan(0))
+}
+
+func TestCalculator_Atan2(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		y, x     float64
+		expected float64
+	}{
+		{"first quadrant", 1, 1, math.Pi / 4},
+		{"second quadrant", 1, -1, 3 * math.Pi / 4},
+		{"third quadrant", -1, -1, -3 * math.Pi / 4},
+		{"fourth quadrant", -1, 1, -math.Pi / 4},
+		{"positive x-axis", 0, 5, 0},
+		{"positive y-axis", 2, 0, math.Pi / 2},
+		{"negative x-axis", 0, -3, math.Pi},
+		{"negative y-axis", -2, 0, -math.Pi / 2},
+		{"thirty degrees", 1, math.Sqrt(3), math.Pi / 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, calc.Atan2(tt.y, tt.x), 1e-12)
+		})
+	}
+}
+
+func TestCalculator_PollardRho(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{"smallest composite", 4},
+		{"odd square", 9},
+		{"small semiprime", 8051},
+		{"carmichael number", 561},
+		{"large semiprime", 1000000007 * 998244353},
+		{"product of two 31-bit primes", 2147483647 * 2147483629},
+		{"prime power", 3 * 3 * 3 * 3 * 3 * 3 * 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := calc.PollardRho(tt.n)
+			require.NoError(t, err)
+			assert.Greater(t, d, 1)
+			assert.Less(t, d, tt.n)
+			assert.Equal(t, 0, tt.n%d)
+		})
+	}
+
+	d, err := calc.PollardRho(1000000007 * 998244353)
+	require.NoError(t, err)
+	assert.Contains(t, []int{1000000007, 998244353}, d)
+
+	errorTests := []struct {
+		name          string
+		n             int
+		expectedError string
+	}{
+		{"prime", 1000000007, "n is prime"},
+		{"small prime", 5, "n is prime"},
+		{"three", 3, "n must be at least 4"},
+		{"one", 1, "n must be at least 4"},
+		{"negative", -15, "n must be at least 4"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.PollardRho(tt.n)
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedError, err.Error())
+		})
+	}
+}
+
+func TestCalculator_PrimeFactorsFast(t *testing.T) {
+	calc := NewCalculator()
+
+	for n := 2; n <= 5000; n++ {
+		factors, err := calc.PrimeFactorsFast(n)
+		require.NoError(t, err)
+		assert.Equal(t, primeFactors(n), factors, "n = %d", n)
+	}
+
+	for _, n := range []int{600851475143, 9999999967 * 3, 1 << 40, 2 * 3 * 5 * 7 * 11 * 13 * 17 * 19 * 23} {
+		factors, err := calc.PrimeFactorsFast(n)
+		require.NoError(t, err)
+		assert.Equal(t, primeFactors(n), factors, "n = %d", n)
+	}
+
+	factors, err := calc.PrimeFactorsFast(1000000007 * 998244353)
+	require.NoError(t, err)
+	assert.Equal(t, []int{998244353, 1000000007}, factors)
+
+	factors, err = calc.PrimeFactorsFast(9223372036854775783)
+	require.NoError(t, err)
+	assert.Equal(t, []int{9223372036854775783}, factors)
+
+	for _, n := range []int{1, 0, -12} {
+		_, err := calc.PrimeFactorsFast(n)
+		assert.Error(t, err)
+		assert.Equal(t, "prime factors are only defined for n >= 2", err.Error())
+	}
+}
+
+func TestCalculator_Primorial(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n        int
+		expected string
+	}{
+		{"zero", 0, "1"},
+		{"one", 1, "1"},
+		{"two", 2, "2"},
+		{"five", 5, "30"},
+		{"six", 6, "30"},
+		{"ten", 10, "210"},
+		{"thirty", 30, "6469693230"},
+		{"beyond int64", 100, "2305567963945518424753102147331756070"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Primorial(tt.n)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.String())
+		})
+	}
+
+	_, err := calc.Primorial(-1)
+	assert.Error(t, err)
+	assert.Equal(t, "primorial is not defined for negative numbers", err.Error())
+}
+
+func TestCalculator_LogBase(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		number        float64
+		base          float64
+		expected      float64
+		expectedError string
+	}{
+		{"base 2 of 8", 8, 2, 3, ""},
+		{"base 5 of 125", 125, 5, 3, ""},
+		{"base 10 of 0.001", 0.001, 10, -3, ""},
+		{"fractional base", 8, 0.5, -3, ""},
+		{"log of one", 1, 7, 0, ""},
+		{"non-integer result", 10, 2, math.Log2(10), ""},
+		{"zero number", 0, 2, 0, "logarithm is not defined for non-positive numbers"},
+		{"negative number", -8, 2, 0, "logarithm is not defined for non-positive numbers"},
+		{"base one", 8, 1, 0, "logarithm base must be positive and not equal to 1"},
+		{"zero base", 8, 0, 0, "logarithm base must be positive and not equal to 1"},
+		{"negative base", 8, -2, 0, "logarithm base must be positive and not equal to 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.LogBase(tt.number, tt.base)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_LeastPrimeFactor(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		n             int
+		expected      int
+		expectedError string
+	}{
+		{"odd composite", 15, 3, ""},
+		{"prime", 17, 17, ""},
+		{"even", 100, 2, ""},
+		{"two", 2, 2, ""},
+		{"square of prime", 49, 7, ""},
+		{"large semiprime", 1000003 * 1000033, 1000003, ""},
+		{"large prime", 1000000007, 1000000007, ""},
+		{"one", 1, 0, "least prime factor is only defined for n >= 2"},
+		{"zero", 0, 0, "least prime factor is only defined for n >= 2"},
+		{"negative", -6, 0, "least prime factor is only defined for n >= 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.LeastPrimeFactor(tt.n)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_IsPrimeFast(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n        int
+		expected bool
+	}{
+		{"two", 2, true},
+		{"three", 3, true},
+		{"small prime", 23, true},
+		{"one", 1, false},
+		{"zero", 0, false},
+		{"negative", -1, false},
+		{"negative prime magnitude", -7, false},
+		{"small composite", 15, false},
+		{"large prime", 1000000007, true},
+		{"prime near 10^9", 999999937, true},
+		{"mersenne prime", 2305843009213693951, true},
+		{"carmichael number", 41041, false},
+		{"strong pseudoprime to bases 2 through 23", 3825123056546413051, false},
+		{"large semiprime", 1000000007 * 998244353, false},
+		{"max int", math.MaxInt64, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calc.IsPrimeFast(tt.n))
+		})
+	}
+
+	// Compare the trial division and Miller-Rabin paths on both sides of the
+	// threshold.
+	for n := trialDivisionLimit - 2000; n <= trialDivisionLimit+2000; n++ {
+		assert.Equal(t, calc.IsPrimeFast(n), primeFactors(n)[0] == n, "n = %d", n)
+		assert.Equal(t, calc.IsPrimeFast(n), calc.IsPrime(n), "n = %d", n)
+	}
+}
+
+func TestCalculator_DigitAt(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		n             int
+		position      int
+		expected      int
+		expectedError string
+	}{
+		{"least significant", 12345, 0, 5, ""},
+		{"most significant", 12345, 4, 1, ""},
+		{"middle", 12345, 2, 3, ""},
+		{"interior zero", 1005, 1, 0, ""},
+		{"negative number", -12345, 1, 4, ""},
+		{"zero", 0, 0, 0, ""},
+		{"min int", math.MinInt64, 18, 9, ""},
+		{"beyond length", 12345, 5, 0, "position exceeds the number of digits"},
+		{"beyond length of zero", 0, 1, 0, "position exceeds the number of digits"},
+		{"negative position", 12345, -1, 0, "position cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.DigitAt(tt.n, tt.position)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_PrimeFactors(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		n             int
+		expected      []int
+		expectedError string
+	}{
+		{"twelve", 12, []int{2, 2, 3}, ""},
+		{"highly composite", 360, []int{2, 2, 2, 3, 3, 5}, ""},
+		{"two", 2, []int{2}, ""},
+		{"prime power", 243, []int{3, 3, 3, 3, 3}, ""},
+		{"large prime", 1000000007, []int{1000000007}, ""},
+		{"large semiprime", 1000003 * 1000033, []int{1000003, 1000033}, ""},
+		{"one", 1, nil, "prime factors are only defined for n >= 2"},
+		{"zero", 0, nil, "prime factors are only defined for n >= 2"},
+		{"negative", -12, nil, "prime factors are only defined for n >= 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.PrimeFactors(tt.n)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_PrimeFactorization(t *testing.T) {
+	calc := NewCalculator()
+
+	result, err := calc.PrimeFactorization(360)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{2: 3, 3: 2, 5: 1}, result)
+
+	result, err = calc.PrimeFactorization(1000000007)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{1000000007: 1}, result)
+
+	result, err = calc.PrimeFactorization(1 << 20)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{2: 20}, result)
+
+	_, err = calc.PrimeFactorization(1)
+	assert.Error(t, err)
+	assert.Equal(t, "prime factors are only defined for n >= 2", err.Error())
+}
+
+func TestCalculator_DigitCount(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n        int
+		expected int
+	}{
+		{"zero", 0, 1},
+		{"single digit", 7, 1},
+		{"multi-digit", 12345, 5},
+		{"negative single digit", -9, 1},
+		{"negative multi-digit", -12345, 5},
+		{"just below power of ten", 999999, 6},
+		{"power of ten", 1000000, 7},
+		{"just below 10^15", 999999999999999, 15},
+		{"10^15", 1000000000000000, 16},
+		{"max int", math.MaxInt64, 19},
+		{"min int", math.MinInt64, 19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calc.DigitCount(tt.n))
+		})
+	}
+}
+
+func TestCalculator_Fibonacci(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		n             int
+		expected      int
+		expectedError string
+	}{
+		{"zero", 0, 0, ""},
+		{"one", 1, 1, ""},
+		{"two", 2, 1, ""},
+		{"ten", 10, 55, ""},
+		{"twenty", 20, 6765, ""},
+		{"largest fitting int", 92, 7540113804746346429, ""},
+		{"overflow", 93, 0, "fibonacci result overflows int"},
+		{"negative", -1, 0, "fibonacci is not defined for negative indices"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Fibonacci(tt.n)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_FibonacciSequence(t *testing.T) {
+	calc := NewCalculator()
+
+	sequence, err := calc.FibonacciSequence(10)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}, sequence)
+
+	sequence, err = calc.FibonacciSequence(1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, sequence)
+
+	sequence, err = calc.FibonacciSequence(0)
+	require.NoError(t, err)
+	assert.Empty(t, sequence)
+
+	sequence, err = calc.FibonacciSequence(93)
+	require.NoError(t, err)
+	assert.Equal(t, 7540113804746346429, sequence[92])
+
+	_, err = calc.FibonacciSequence(94)
+	assert.EqualError(t, err, "fibonacci result overflows int")
+
+	_, err = calc.FibonacciSequence(-1)
+	assert.EqualError(t, err, "count cannot be negative")
+}
+
+func TestCalculator_FibonacciBig(t *testing.T) {
+	calc := NewCalculator()
+
+	for n := 0; n <= 92; n++ {
+		expected, err := calc.Fibonacci(n)
+		require.NoError(t, err)
+		result, err := calc.FibonacciBig(n)
+		require.NoError(t, err)
+		assert.Equal(t, int64(expected), result.Int64(), "n = %d", n)
+	}
+
+	result, err := calc.FibonacciBig(100)
+	require.NoError(t, err)
+	assert.Equal(t, "354224848179261915075", result.String())
+
+	_, err = calc.FibonacciBig(-5)
+	assert.EqualError(t, err, "fibonacci is not defined for negative indices")
+}
+
+func TestCalculator_BitLength(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		n        int
+		expected int
+	}{
+		{"zero", 0, 0},
+		{"one", 1, 1},
+		{"byte max", 255, 8},
+		{"just above byte", 256, 9},
+		{"negative", -255, 8},
+		{"negative one", -1, 1},
+		{"max int", math.MaxInt64, 63},
+		{"min int", math.MinInt64, 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calc.BitLength(tt.n))
+		})
+	}
+}
+
+func TestCalculator_Combinations(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		n, r          int
+		expected      int
+		expectedError string
+	}{
+		{"five choose two", 5, 2, 10, ""},
+		{"poker hands", 52, 5, 2598960, ""},
+		{"choose none", 7, 0, 1, ""},
+		{"choose all", 7, 7, 1, ""},
+		{"zero choose zero", 0, 0, 1, ""},
+		{"factorials would overflow", 60, 30, 118264581564861424, ""},
+		{"large n small r", 1000000, 3, 166666166667000000, ""},
+		{"result overflows", 70, 35, 0, "combination result overflows int"},
+		{"r exceeds n", 3, 5, 0, "r cannot exceed n"},
+		{"negative n", -5, 2, 0, "n and r cannot be negative"},
+		{"negative r", 5, -2, 0, "n and r cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Combinations(tt.n, tt.r)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+
+	// Every value of C(n, r) that fits in an int must match big.Int.Binomial.
+	for n := 0; n <= 66; n++ {
+		for r := 0; r <= n; r++ {
+			expected := new(big.Int).Binomial(int64(n), int64(r))
+			result, err := calc.Combinations(n, r)
+			if expected.IsInt64() {
+				require.NoError(t, err, "C(%d, %d)", n, r)
+				assert.Equal(t, expected.Int64(), int64(result), "C(%d, %d)", n, r)
+			} else {
+				assert.Error(t, err, "C(%d, %d)", n, r)
+			}
+		}
+	}
+}
+
+func TestCalculator_Permutations(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		n, r          int
+		expected      int
+		expectedError string
+	}{
+		{"five permute two", 5, 2, 20, ""},
+		{"permute none", 5, 0, 1, ""},
+		{"permute all", 5, 5, 120, ""},
+		{"poker hands in order", 52, 5, 311875200, ""},
+		{"large n small r", 1000000, 2, 999999000000, ""},
+		{"result overflows", 52, 20, 0, "permutation result overflows int"},
+		{"r exceeds n", 2, 3, 0, "r cannot exceed n"},
+		{"negative", -1, 0, 0, "n and r cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Permutations(tt.n, tt.r)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_CombinationsPermutationsBig(t *testing.T) {
+	calc := NewCalculator()
+
+	combinations, err := calc.CombinationsBig(52, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "2598960", combinations.String())
+
+	combinations, err = calc.CombinationsBig(100, 50)
+	require.NoError(t, err)
+	assert.Equal(t, "100891344545564193334812497256", combinations.String())
+
+	permutations, err := calc.PermutationsBig(5, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "20", permutations.String())
+
+	permutations, err = calc.PermutationsBig(30, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "1", permutations.String())
+
+	permutations, err = calc.PermutationsBig(math.MaxInt, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "1", permutations.String())
+
+	permutations, err = calc.PermutationsBig(math.MaxInt, 1)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(math.MaxInt), permutations)
+
+	permutations, err = calc.PermutationsBig(25, 25)
+	require.NoError(t, err)
+	assert.Equal(t, "15511210043330985984000000", permutations.String())
+
+	_, err = calc.CombinationsBig(3, 4)
+	assert.EqualError(t, err, "r cannot exceed n")
+	_, err = calc.PermutationsBig(-3, 1)
+	assert.EqualError(t, err, "n and r cannot be negative")
+}
+
+func TestCalculator_RotateBits(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		x             uint64
+		k             int
+		expectedLeft  uint64
+		expectedRight uint64
+	}{
+		{"no rotation", 0xF0, 0, 0xF0, 0xF0},
+		{"by four", 0xF0, 4, 0xF00, 0x0F},
+		{"wrap around", 0x8000000000000001, 1, 0x3, 0xC000000000000000},
+		{"pattern by eight", 0x0123456789ABCDEF, 8, 0x23456789ABCDEF01, 0xEF0123456789ABCD},
+		{"full rotation", 0x0123456789ABCDEF, 64, 0x0123456789ABCDEF, 0x0123456789ABCDEF},
+		{"more than full rotation", 0xF0, 68, 0xF00, 0x0F},
+		{"negative k", 0xF0, -4, 0x0F, 0xF00},
+		{"large negative k", 0xF0, -68, 0x0F, 0xF00},
+		{"min int k", 0xF0, math.MinInt64, 0xF0, 0xF0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedLeft, calc.RotateLeft(tt.x, tt.k))
+			assert.Equal(t, tt.expectedRight, calc.RotateRight(tt.x, tt.k))
+		})
+	}
+
+	for k := 0; k < 64; k++ {
+		assert.Equal(t, uint64(0xDEADBEEF), calc.RotateRight(calc.RotateLeft(0xDEADBEEF, k), k))
+	}
+}
+
+func TestCalculator_GCDSlice(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		values        []int
+		expected      int
+		expectedError string
+	}{
+		{"three values", []int{12, 18, 24}, 6, ""},
+		{"four values", []int{100, 75, 50, 25}, 25, ""},
+		{"coprime", []int{6, 10, 15}, 1, ""},
+		{"with negatives", []int{-12, 18, -30}, 6, ""},
+		{"with zero", []int{0, 8, 12}, 4, ""},
+		{"single value", []int{-7}, 7, ""},
+		{"all zeros", []int{0, 0, 0}, 0, ""},
+		{"large coprime", []int{1<<60 + 1, 3}, 1, ""},
+		{"large with common factor", []int{1<<53 + 1, 3}, 3, ""},
+		{"large odd with even", []int{1<<60 + 3, 6}, 1, ""},
+		{"empty", []int{}, 0, "values cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.GCDSlice(tt.values)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_LCMSlice(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		values        []int
+		expected      int
+		expectedError string
+	}{
+		{"three values", []int{4, 6, 8}, 24, ""},
+		{"four values", []int{2, 3, 4, 5}, 60, ""},
+		{"with negatives", []int{-4, 6}, 12, ""},
+		{"with zero", []int{4, 0, 6}, 0, ""},
+		{"single value", []int{-9}, 9, ""},
+		{"first twenty", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, 232792560, ""},
+		{"overflow", []int{1 << 40, 3, 5, 7, 11, 13, 17, 19, 23, 29}, 0, "lcm result overflows int"},
+		{"empty", nil, 0, "values cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.LCMSlice(tt.values)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_ReverseBits(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		x        uint64
+		expected uint64
+	}{
+		{"zero", 0, 0},
+		{"one", 1, 1 << 63},
+		{"high bit", 1 << 63, 1},
+		{"all ones", math.MaxUint64, math.MaxUint64},
+		{"low byte", 0xFF, 0xFF00000000000000},
+		{"pattern", 0x0123456789ABCDEF, 0xF7B3D591E6A2C480},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calc.ReverseBits(tt.x))
+			assert.Equal(t, tt.x, calc.ReverseBits(calc.ReverseBits(tt.x)))
+		})
+	}
+}
+
+func TestCalculator_Percentage(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		value, total  float64
+		expected      float64
+		expectedError string
+	}{
+		{"quarter of total", 25, 200, 12.5, ""},
+		{"whole", 80, 80, 100, ""},
+		{"more than total", 30, 20, 150, ""},
+		{"negative value", -5, 20, -25, ""},
+		{"zero total", 5, 0, 0, "total cannot be zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Percentage(tt.value, tt.total)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_PercentOf(t *testing.T) {
+	calc := NewCalculator()
+
+	assert.InDelta(t, 25.0, calc.PercentOf(12.5, 200), 1e-12)
+	assert.InDelta(t, 7.5, calc.PercentOf(150, 5), 1e-12)
+	assert.InDelta(t, -3.0, calc.PercentOf(-10, 30), 1e-12)
+	assert.Equal(t, 0.0, calc.PercentOf(0, 1234))
+}
+
+func TestCalculator_PercentChange(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name           string
+		oldVal, newVal float64
+		expected       float64
+		expectedError  string
+	}{
+		{"increase", 50, 75, 50, ""},
+		{"decrease", 80, 60, -25, ""},
+		{"no change", 42, 42, 0, ""},
+		{"doubling", 10, 20, 100, ""},
+		{"increase from negative", -50, -25, 50, ""},
+		{"decrease from negative", -50, -75, -50, ""},
+		{"zero original", 0, 10, 0, "original value cannot be zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.PercentChange(tt.oldVal, tt.newVal)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_ReduceFraction(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		num, den      int
+		expectedNum   int
+		expectedDen   int
+		expectedError string
+	}{
+		{"negative denominator", 4, -8, -1, 2, ""},
+		{"both negative", -6, -9, 2, 3, ""},
+		{"negative numerator", -10, 4, -5, 2, ""},
+		{"already reduced", 3, 7, 3, 7, ""},
+		{"whole number", 12, 4, 3, 1, ""},
+		{"zero numerator", 0, 5, 0, 1, ""},
+		{"zero numerator negative denominator", 0, -5, 0, 1, ""},
+		{"large values", 1 << 60, 3 << 58, 4, 3, ""},
+		{"min int over negative denominator", math.MinInt, -2, 1 << 62, 1, ""},
+		{"min int over itself", math.MinInt, math.MinInt, 1, 1, ""},
+		{"zero denominator", 1, 0, 0, 0, "denominator cannot be zero"},
+		{"min int numerator overflows", math.MinInt, -1, 0, 0, "fraction overflows int"},
+		{"min int denominator overflows", 1, math.MinInt, 0, 0, "fraction overflows int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			num, den, err := calc.ReduceFraction(tt.num, tt.den)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedNum, num)
+				assert.Equal(t, tt.expectedDen, den)
+			}
+		})
+	}
+}
+
+func TestCalculator_Clamp(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		value         float64
+		min, max      float64
+		expected      float64
+		expectedError string
+	}{
+		{"below range", -5, 0, 10, 0, ""},
+		{"within range", 5, 0, 10, 5, ""},
+		{"above range", 15, 0, 10, 10, ""},
+		{"at lower bound", 0, 0, 10, 0, ""},
+		{"at upper bound", 10, 0, 10, 10, ""},
+		{"empty range", 3, 7, 7, 7, ""},
+		{"inverted range", 5, 10, 0, 0, "lower bound must not exceed upper bound"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Clamp(tt.value, tt.min, tt.max)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_Lerp(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		a, b, t  float64
+		expected float64
+	}{
+		{"start", 10, 20, 0, 10},
+		{"midpoint", 10, 20, 0.5, 15},
+		{"end", 10, 20, 1, 20},
+		{"decreasing", 20, 10, 0.25, 17.5},
+		{"extrapolate", 0, 10, 1.5, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, calc.Lerp(tt.a, tt.b, tt.t), 1e-12)
+		})
+	}
+}
+
+func TestCalculator_MapRange(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name           string
+		value          float64
+		inMin, inMax   float64
+		outMin, outMax float64
+		expected       float64
+		expectedError  string
+	}{
+		{"percent scale", 5, 0, 10, 0, 100, 50, ""},
+		{"celsius to fahrenheit", 100, 0, 100, 32, 212, 212, ""},
+		{"inverted output", 2, 0, 10, 100, 0, 80, ""},
+		{"inverted input", 8, 10, 0, 0, 1, 0.2, ""},
+		{"outside input range", 15, 0, 10, 0, 100, 150, ""},
+		{"empty input range", 5, 3, 3, 0, 100, 0, "input range must have non-zero width"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.MapRange(tt.value, tt.inMin, tt.inMax, tt.outMin, tt.outMax)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_CompareFractions(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name           string
+		n1, d1, n2, d2 int
+		expected       int
+		expectedError  string
+	}{
+		{"equal", 1, 3, 2, 6, 0, ""},
+		{"less", 1, 3, 1, 2, -1, ""},
+		{"greater", 3, 4, 2, 3, 1, ""},
+		{"negative fractions", -1, 2, -1, 3, -1, ""},
+		{"negative denominator", 1, -2, 1, 3, -1, ""},
+		{"both denominators negative", 1, -2, 1, -3, -1, ""},
+		{"equal with signs moved", -2, 3, 2, -3, 0, ""},
+		{"zero fractions", 0, 5, 0, -7, 0, ""},
+		{"products overflow int", math.MaxInt64, math.MaxInt64 - 1, math.MaxInt64 - 1, math.MaxInt64 - 2, -1, ""},
+		{"first denominator zero", 1, 0, 1, 2, 0, "denominator cannot be zero"},
+		{"second denominator zero", 1, 2, 1, 0, 0, "denominator cannot be zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.CompareFractions(tt.n1, tt.d1, tt.n2, tt.d2)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_Mediant(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name           string
+		n1, d1, n2, d2 int
+		expectedNum    int
+		expectedDen    int
+		expectedError  string
+	}{
+		{"half and third", 1, 2, 1, 3, 2, 5, ""},
+		{"farey neighbors", 0, 1, 1, 1, 1, 2, ""},
+		{"reduced result", 1, 3, 3, 5, 1, 2, ""},
+		{"negative fractions", -1, 2, -1, 3, -2, 5, ""},
+		{"first denominator zero", 1, 0, 1, 2, 0, 0, "denominator cannot be zero"},
+		{"second denominator zero", 1, 2, 1, 0, 0, 0, "denominator cannot be zero"},
+		{"combined denominator zero", 1, 2, 1, -2, 0, 0, "combined denominator cannot be zero"},
+		{"numerator overflow", math.MaxInt, 2, 1, 3, 0, 0, "fraction overflows int"},
+		{"negative numerator overflow", math.MinInt, 2, -1, 3, 0, 0, "fraction overflows int"},
+		{"denominator overflow", 1, math.MaxInt, 1, 1, 0, 0, "fraction overflows int"},
+		{"large values that fit", math.MaxInt - 1, 1, 1, 1, math.MaxInt, 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			num, den, err := calc.Mediant(tt.n1, tt.d1, tt.n2, tt.d2)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedNum, num)
+				assert.Equal(t, tt.expectedDen, den)
+			}
+		})
+	}
+
+	// The mediant of two unequal fractions lies strictly between them.
+	pairs := [][4]int{{1, 3, 1, 2}, {2, 7, 5, 8}, {-3, 4, 1, 9}, {7, 3, 11, 5}}
+	for _, p := range pairs {
+		num, den, err := calc.Mediant(p[0], p[1], p[2], p[3])
+		require.NoError(t, err)
+		low, err := calc.CompareFractions(p[0], p[1], num, den)
+		require.NoError(t, err)
+		high, err := calc.CompareFractions(num, den, p[2], p[3])
+		require.NoError(t, err)
+		ordered, err := calc.CompareFractions(p[0], p[1], p[2], p[3])
+		require.NoError(t, err)
+		assert.Equal(t, ordered, low, "pair %v", p)
+		assert.Equal(t, ordered, high, "pair %v", p)
+	}
+}
+
+func TestCalculator_SumProduct(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name            string
+		values          []float64
+		expectedSum     float64
+		expectedProduct float64
+	}{
+		{"mixed values", []float64{2, -3, 0.5, 4}, 3.5, -12},
+		{"single value", []float64{7}, 7, 7},
+		{"with zero", []float64{1, 0, 5}, 6, 0},
+		{"empty", []float64{}, 0, 1},
+		{"nil", nil, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expectedSum, calc.Sum(tt.values), 1e-12)
+			assert.InDelta(t, tt.expectedProduct, calc.Product(tt.values), 1e-12)
+		})
+	}
+}
+
+func TestCalculator_CumulativeSum(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		values   []float64
+		expected []float64
+	}{
+		{"mixed values", []float64{2, -3, 0.5, 4}, []float64{2, -1, -0.5, 3.5}},
+		{"single value", []float64{7}, []float64{7}},
+		{"empty", []float64{}, []float64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calc.CumulativeSum(tt.values)
+			assert.Len(t, result, len(tt.values))
+			assert.InDeltaSlice(t, tt.expected, result, 1e-12)
+			if len(result) > 0 {
+				assert.InDelta(t, calc.Sum(tt.values), result[len(result)-1], 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_SortedSum(t *testing.T) {
+	calc := NewCalculator()
+
+	// Each 1 is lost to rounding when added directly to 1e16.
+	values := []float64{1e16, 1, 1, 1, 1, -1e16}
+	original := append([]float64(nil), values...)
+	assert.Equal(t, 0.0, calc.Sum(values))
+	assert.Equal(t, 4.0, calc.SortedSum(values))
+	assert.Equal(t, original, values, "input should not be modified")
+
+	values = make([]float64, 0, 10001)
+	values = append(values, 1)
+	for i := 0; i < 10000; i++ {
+		values = append(values, 1e-16)
+	}
+	assert.Equal(t, 1.0, calc.Sum(values))
+	assert.InDelta(t, 1+1e-12, calc.SortedSum(values), 1e-15)
+
+	assert.Equal(t, 6.0, calc.SortedSum([]float64{3, -2, 5}))
+	assert.Equal(t, 0.0, calc.SortedSum([]float64{}))
+	assert.Equal(t, 0.0, calc.SortedSum(nil))
+}
+
+func TestCalculator_PowerInt(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		base          int
+		exponent      int
+		expected      int
+		expectedError string
+	}{
+		{"two to the ten", 2, 10, 1024, ""},
+		{"ten to the eighteen", 10, 18, 1000000000000000000, ""},
+		{"zero exponent", 7, 0, 1, ""},
+		{"zero to the zero", 0, 0, 1, ""},
+		{"zero base", 0, 5, 0, ""},
+		{"one to a large power", 1, math.MaxInt64, 1, ""},
+		{"negative one to an odd power", -1, 1<<40 + 1, -1, ""},
+		{"negative base even exponent", -3, 4, 81, ""},
+		{"negative base odd exponent", -3, 3, -27, ""},
+		{"largest power of two", 2, 62, 1 << 62, ""},
+		{"min int", -2, 63, math.MinInt64, ""},
+		{"large base first power", math.MaxInt64, 1, math.MaxInt64, ""},
+		{"power of two overflow", 2, 63, 0, "power result overflows int"},
+		{"ten to the nineteen", 10, 19, 0, "power result overflows int"},
+		{"negative overflow", -2, 65, 0, "power result overflows int"},
+		{"square overflows", 1 << 32, 2, 0, "power result overflows int"},
+		{"negative exponent", 2, -1, 0, "exponent cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.PowerInt(tt.base, tt.exponent)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+
+	// Compare with big.Int.Exp over a grid of small bases and exponents.
+	for base := -12; base <= 12; base++ {
+		for exponent := 0; exponent <= 70; exponent++ {
+			expected := new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(exponent)), nil)
+			result, err := calc.PowerInt(base, exponent)
+			if expected.IsInt64() {
+				require.NoError(t, err, "%d^%d", base, exponent)
+				assert.Equal(t, expected.Int64(), int64(result), "%d^%d", base, exponent)
+			} else {
+				assert.Error(t, err, "%d^%d", base, exponent)
+			}
+		}
+	}
+}
+
+func TestCalculator_IsSorted(t *testing.T) {
+	calc := NewCalculator()
+	nan := math.NaN()
+
+	tests := []struct {
+		name      string
+		values    []float64
+		ascending bool
+		expected  bool
+	}{
+		{"ascending", []float64{1, 2, 2, 3.5, 10}, true, true},
+		{"ascending checked as descending", []float64{1, 2, 3}, false, false},
+		{"descending", []float64{9, 4, 4, -1}, false, true},
+		{"descending checked as ascending", []float64{3, 2, 1}, true, false},
+		{"unsorted ascending", []float64{1, 3, 2}, true, false},
+		{"unsorted descending", []float64{3, 1, 2}, false, false},
+		{"all equal", []float64{5, 5, 5}, true, true},
+		{"single element", []float64{42}, true, true},
+		{"single element descending", []float64{42}, false, true},
+		{"empty", []float64{}, true, true},
+		{"nil descending", nil, false, true},
+		{"infinities", []float64{math.Inf(-1), 0, math.Inf(1)}, true, true},
+		{"leading NaN ascending", []float64{nan, nan, 1, 2}, true, true},
+		{"NaN after number ascending", []float64{1, nan, 2}, true, false},
+		{"trailing NaN descending", []float64{2, 1, nan}, false, true},
+		{"leading NaN descending", []float64{nan, 2, 1}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calc.IsSorted(tt.values, tt.ascending))
+		})
+	}
+}
+
+func TestCalculator_DegreeTrigonometric(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name        string
+		degrees     float64
+		expectedSin float64
+		expectedCos float64
+		expectedTan float64
+	}{
+		{"zero", 0, 0, 1, 0},
+		{"thirty", 30, 0.5, math.Sqrt(3) / 2, 1 / math.Sqrt(3)},
+		{"forty-five", 45, math.Sqrt2 / 2, math.Sqrt2 / 2, 1},
+		{"ninety", 90, 1, 0, math.Inf(1)},
+		{"one eighty", 180, 0, -1, 0},
+		{"negative", -60, -math.Sqrt(3) / 2, 0.5, -math.Sqrt(3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expectedSin, calc.SinDeg(tt.degrees), 1e-12)
+			assert.InDelta(t, tt.expectedCos, calc.CosDeg(tt.degrees), 1e-12)
+			if math.IsInf(tt.expectedTan, 0) {
+				assert.Greater(t, math.Abs(calc.TanDeg(tt.degrees)), 1e15)
+			} else {
+				assert.InDelta(t, tt.expectedTan, calc.TanDeg(tt.degrees), 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_Ranks(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		values   []float64
+		expected []float64
+	}{
+		{"no ties", []float64{30, 10, 20}, []float64{3, 1, 2}},
+		{"pair tie", []float64{10, 20, 20, 30}, []float64{1, 2.5, 2.5, 4}},
+		{"triple tie unsorted", []float64{5, 1, 5, 0, 5}, []float64{4, 2, 4, 1, 4}},
+		{"all equal", []float64{7, 7, 7, 7}, []float64{2.5, 2.5, 2.5, 2.5}},
+		{"single value", []float64{-3}, []float64{1}},
+		{"negative values", []float64{-1, -5, 0}, []float64{2, 1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]float64(nil), tt.values...)
+			result, err := calc.Ranks(tt.values)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, original, tt.values, "input should not be modified")
+		})
+	}
+
+	_, err := calc.Ranks([]float64{})
+	assert.Error(t, err)
+	assert.Equal(t, "values cannot be empty", err.Error())
+}
+
+func TestCalculator_SolveQuadratic(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		a, b, c       float64
+		expected      []float64
+		expectedError string
+	}{
+		{"two roots", 1, -5, 6, []float64{2, 3}, ""},
+		{"two roots negative leading coefficient", -1, 5, -6, []float64{2, 3}, ""},
+		{"roots of opposite sign", 2, 0, -8, []float64{-2, 2}, ""},
+		{"zero root", 1, 3, 0, []float64{-3, 0}, ""},
+		{"double root", 1, -4, 4, []float64{2}, ""},
+		{"no real roots", 1, 0, 1, []float64{}, ""},
+		{"tiny root without cancellation", 1, -1e8, 1, []float64{1e-8, 1e8}, ""},
+		{"not quadratic", 0, 2, 1, nil, "coefficient a cannot be zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.SolveQuadratic(tt.a, tt.b, tt.c)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result, len(tt.expected))
+			for i := range tt.expected {
+				assert.InDelta(t, tt.expected[i], result[i], 1e-12*math.Max(1, math.Abs(tt.expected[i])), "root %d", i)
+			}
+		})
+	}
+}
+
+func TestCalculator_SpearmanCorrelation(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		x, y          []float64
+		expected      float64
+		expectedError string
+	}{
+		{"monotonic nonlinear", []float64{1, 2, 3, 4, 5}, []float64{1, 8, 27, 64, 125}, 1, ""},
+		{"exponential growth", []float64{0, 1, 2, 3}, []float64{1, math.E, math.Exp(2), math.Exp(20)}, 1, ""},
+		{"inverse", []float64{1, 2, 3, 4}, []float64{100, 10, 1, 0.1}, -1, ""},
+		{"partial agreement", []float64{1, 2, 3, 4, 5}, []float64{2, 1, 4, 3, 5}, 0.8, ""},
+		{"with ties", []float64{1, 2, 2, 3}, []float64{1, 2, 3, 4}, 0.9486832980505138, ""},
+		{"length mismatch", []float64{1, 2, 3}, []float64{1, 2}, 0, "x and y must have the same length"},
+		{"single point", []float64{1}, []float64{2}, 0, "correlation requires at least two points"},
+		{"empty", nil, nil, 0, "correlation requires at least two points"},
+		{"constant x", []float64{3, 3, 3}, []float64{1, 2, 3}, 0, "correlation is undefined for constant values"},
+		{"constant y", []float64{1, 2, 3}, []float64{5, 5, 5}, 0, "correlation is undefined for constant values"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.SpearmanCorrelation(tt.x, tt.y)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_FactorialBigContext(t *testing.T) {
+	calc := NewCalculator()
+
+	for _, n := range []int{0, 1, 20, 1023, 1024, 1025, 5000} {
+		expected, err := calc.FactorialBig(n)
+		require.NoError(t, err)
+		result, err := calc.FactorialBigContext(context.Background(), n)
+		require.NoError(t, err)
+		assert.Equal(t, 0, expected.Cmp(result), "n = %d", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := calc.FactorialBigContext(ctx, 100000000)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Without periodic checks this would run for a very long time.
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = calc.FactorialBigContext(ctx, 100000000)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = calc.FactorialBigContext(context.Background(), -1)
+	assert.EqualError(t, err, "factorial is not defined for negative numbers")
+}
+
+func TestCalculator_IsPrimeContext(t *testing.T) {
+	calc := NewCalculator()
+
+	for _, n := range []int{-7, 0, 1, 2, 17, 561, trialDivisionLimit + 7, 1000000007, 1000000007 * 998244353, 9223372036854775783} {
+		result, err := calc.IsPrimeContext(context.Background(), n)
+		require.NoError(t, err)
+		assert.Equal(t, calc.IsPrime(n), result, "n = %d", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := calc.IsPrimeContext(ctx, 1000000007)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = calc.IsPrimeContext(ctx, 7)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCalculator_CommonRatio(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		term1, term2  float64
+		expected      float64
+		expectedError string
+	}{
+		{"growing", 2, 8, 4, ""},
+		{"shrinking", 8, 2, 0.25, ""},
+		{"alternating", 3, -6, -2, ""},
+		{"zero second term", 5, 0, 0, ""},
+		{"zero first term", 0, 8, 0, "first term cannot be zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.CommonRatio(tt.term1, tt.term2)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.expected, result, 1e-12)
+			}
+		})
+	}
+}
+
+func TestCalculator_CommonDifference(t *testing.T) {
+	calc := NewCalculator()
+
+	assert.Equal(t, 4.0, calc.CommonDifference(3, 7))
+	assert.Equal(t, -4.0, calc.CommonDifference(7, 3))
+	assert.Equal(t, 0.0, calc.CommonDifference(5, 5))
+	assert.InDelta(t, 0.1, calc.CommonDifference(0.2, 0.3), 1e-12)
+}
+
+// TestCalculator_ConcurrentMemory is most useful under go test -race.
+func TestCalculator_ConcurrentMemory(t *testing.T) {
+	calc := NewCalculator()
+	calc.EnableHistory(true)
+
+	const goroutines = 50
+	const adds = 200
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < adds; i++ {
+				calc.MemoryAdd(float64(g))
+				calc.MemoryRecall()
+				calc.Add(float64(g), float64(i))
+				if i%50 == 0 {
+					calc.History()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	expected := 0.0
+	for g := 0; g < goroutines; g++ {
+		expected += float64(g) * adds
+	}
+	assert.Equal(t, expected, calc.MemoryRecall())
+	assert.Len(t, calc.History(), goroutines*adds)
+}
+
+func TestCalculator_DetectSequenceType(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name          string
+		values        []float64
+		expected      string
+		expectedError string
+	}{
+		{"arithmetic", []float64{2, 4, 6, 8}, "arithmetic", ""},
+		{"decreasing arithmetic", []float64{10, 7, 4, 1, -2}, "arithmetic", ""},
+		{"arithmetic with rounding", []float64{0.1, 0.2, 0.3, 0.4}, "arithmetic", ""},
+		{"constant", []float64{3, 3, 3}, "arithmetic", ""},
+		{"geometric", []float64{2, 4, 8, 16}, "geometric", ""},
+		{"alternating geometric", []float64{1, -3, 9, -27}, "geometric", ""},
+		{"fractional ratio", []float64{81, 27, 9, 3, 1}, "geometric", ""},
+		{"geometric with rounding", []float64{1, 1.1, 1.21, 1.331}, "geometric", ""},
+		{"irregular", []float64{1, 2, 4, 7, 11}, "none", ""},
+		{"zero term", []float64{0, 2, 4, 7}, "none", ""},
+		{"zeros after start", []float64{5, 0, 0}, "none", ""},
+		{"two values", []float64{1, 2}, "", "sequence requires at least three values"},
+		{"empty", nil, "", "sequence requires at least three values"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.DetectSequenceType(tt.values)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculator_InfixToPostfixFunctions(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name     string
+		expr     string
+		postfix  []string
+		expected float64
+	}{
+		{"single call", "sqrt(16)", []string{"16", "sqrt"}, 4},
+		{"call plus call", "sqrt(16) + sin(0)", []string{"16", "sqrt", "0", "sin", "+"}, 4},
+		{"nested calls", "sqrt(sqrt(81))", []string{"81", "sqrt", "sqrt"}, 3},
+		{"expression argument", "log(2 ^ 3 - 7) * 2", []string{"2", "3", "^", "7", "-", "log", "2", "*"}, 0},
+		{"negated call", "-cos(0) ^ 2", []string{"0", "cos", "2", "^", "neg"}, -1},
+		{"power of call", "sqrt(4) ^ 3", []string{"4", "sqrt", "3", "^"}, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postfix, err := calc.InfixToPostfix(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.postfix, postfix)
+
+			result, err := calc.EvalRPN(postfix)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-12)
+
+			direct, err := calc.Evaluate(tt.expr)
+			require.NoError(t, err)
+			assert.InDelta(t, direct, result, 1e-12)
+		})
+	}
+
+	_, err := calc.InfixToPostfix("tan(1)")
+	assert.Error(t, err)
+	assert.Equal(t, "unknown function: tan", err.Error())
+}